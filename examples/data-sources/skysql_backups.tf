@@ -0,0 +1,7 @@
+# List the successful full backups of a service taken since the start of the month
+data "skysql_backups" "default" {
+  service_id    = skysql_service.default.id
+  status        = "succeeded"
+  backup_type   = "full"
+  started_after = "2024-05-01T00:00:00Z"
+}
@@ -7,4 +7,29 @@ resource "skysql_allow_list" "default" {
     }
   ]
   wait_for_creation = true
-}
\ No newline at end of file
+}
+
+# Only manage the office entry, entries added in the portal are kept.
+resource "skysql_allow_list" "office" {
+  service_id = skysql_service.default.id
+  exclusive  = false
+  allow_list = [
+    {
+      "ip" : "192.158.1.38/32",
+      "comment" : "homeoffice"
+    }
+  ]
+}
+
+# Temporary access, removed on the first apply after it expired.
+resource "skysql_allow_list" "incident" {
+  service_id = skysql_service.default.id
+  exclusive  = false
+  allow_list = [
+    {
+      "ip" : "198.51.100.7/32",
+      "comment" : "incident responder",
+      "expires_at" : "2026-11-01T00:00:00Z"
+    }
+  ]
+}
@@ -0,0 +1,6 @@
+# Each team adds its own entries, entries of other teams are left unchanged.
+resource "skysql_allow_list_entry" "reporting" {
+  service_id = skysql_service.default.id
+  ip         = "203.0.113.0/24"
+  comment    = "reporting team"
+}
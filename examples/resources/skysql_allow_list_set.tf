@@ -0,0 +1,18 @@
+# The office entries are applied to every service of the fleet.
+resource "skysql_allow_list_set" "office" {
+  service_ids = [skysql_service.orders.id, skysql_service.billing.id]
+  entries = [
+    {
+      "ip" : "192.158.1.38/32",
+      "comment" : "office"
+    },
+    {
+      "ip" : "203.0.113.0/24",
+      "comment" : "vpn"
+    }
+  ]
+}
+
+output "failed_services" {
+  value = [for r in skysql_allow_list_set.office.results : r.service_id if !r.success]
+}
@@ -1 +1,15 @@
-resource "skysql_autonomous" "default" {}
\ No newline at end of file
+resource "skysql_autonomous" "default" {
+  service_id = skysql_service.default.id
+
+  auto_scale_disk = {
+    max_storage_size_gbs = 300
+  }
+
+  action {
+    group = "autoScaleNodesHorizontal"
+    params = jsonencode({
+      min_nodes = 2
+      max_nodes = 4
+    })
+  }
+}
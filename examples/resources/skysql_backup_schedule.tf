@@ -0,0 +1,18 @@
+resource "skysql_backup_schedule" "nightly" {
+  service_id     = skysql_service.default.id
+  backup_type    = "full"
+  schedule       = "0 3 * * *"
+  retention_days = 14
+}
+
+# Write incremental backups to a bucket outside of SkySQL.
+resource "skysql_backup_schedule" "hourly" {
+  service_id  = skysql_service.default.id
+  backup_type = "incremental"
+  schedule    = "0 * * * *"
+
+  external_storage = {
+    bucket_path = "s3://my-bucket/backups"
+    credentials = var.backup_bucket_credentials
+  }
+}
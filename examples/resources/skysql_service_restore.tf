@@ -0,0 +1,17 @@
+# Roll the service back to the last successful full backup.
+# Change the trigger to run the restore again.
+data "skysql_backups" "full" {
+  service_id  = skysql_service.default.id
+  status      = "succeeded"
+  backup_type = "full"
+}
+
+resource "skysql_service_restore" "rollback" {
+  service_id            = skysql_service.default.id
+  backup_id             = data.skysql_backups.full.backups[length(data.skysql_backups.full.backups) - 1].id
+  acknowledge_data_loss = true
+
+  triggers = {
+    migration = "0042_add_orders_index"
+  }
+}
@@ -5,7 +5,6 @@ go 1.23.0
 toolchain go1.23.8
 
 require (
-	github.com/asaskevich/govalidator v0.0.0-20210307081110-f21760c49a8d
 	github.com/go-resty/resty/v2 v2.7.0
 	github.com/google/uuid v1.6.0
 	github.com/hashicorp/terraform-plugin-docs v0.13.0
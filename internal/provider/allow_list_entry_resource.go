@@ -0,0 +1,305 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql/provisioning"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &AllowListEntryResource{}
+var _ resource.ResourceWithImportState = &AllowListEntryResource{}
+var _ resource.ResourceWithConfigure = &AllowListEntryResource{}
+
+func NewAllowListEntryResource() resource.Resource {
+	return &AllowListEntryResource{}
+}
+
+// AllowListEntryResource defines the resource implementation.
+type AllowListEntryResource struct {
+	client *skysql.Client
+}
+
+// AllowListEntryResourceModel describes the resource data model.
+type AllowListEntryResourceModel struct {
+	ID        types.String   `tfsdk:"id"`
+	ServiceID types.String   `tfsdk:"service_id"`
+	IPAddress CIDRValue      `tfsdk:"ip"`
+	Comment   types.String   `tfsdk:"comment"`
+	Timeouts  timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *AllowListEntryResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_allow_list_entry"
+}
+
+func (r *AllowListEntryResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a single entry of the allow list of a service. Entries of the same service can be owned by different configurations, " +
+			"the other entries of the list are left unchanged. Do not combine with skysql_allow_list or allow_list of skysql_service for the same service. " +
+			"Changes are serialized within a single Terraform run only, the writes carry no precondition, so a change made at the same time " +
+			"outside of it, e.g. by another run or the portal, can be lost",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the entry, in the format <service_id>/<ip>",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"service_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the service to add the entry to",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ip": schema.StringAttribute{
+				Required:    true,
+				CustomType:  CIDRType{},
+				Description: "The IP address to allow access to the service. The IP must be an IPv4 or IPv6 address or a range in CIDR format",
+				Validators: []validator.String{
+					allowListIPValidator{},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"comment": schema.StringAttribute{
+				Optional:    true,
+				Description: "A comment to describe the IP address",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *AllowListEntryResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*skysql.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *skysql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *AllowListEntryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *AllowListEntryResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serviceID := data.ServiceID.ValueString()
+	ip := data.IPAddress.ValueString()
+
+	tflog.Info(ctx, "Adding allow list entry", map[string]interface{}{
+		"service_id": serviceID,
+		"ip":         ip,
+	})
+
+	_, err := modifyAllowList(ctx, r.client, serviceID, createTimeout, func(allowList []provisioning.AllowListItem) ([]provisioning.AllowListItem, error) {
+		if findAllowListItem(allowList, ip) >= 0 {
+			return nil, fmt.Errorf("%s is already on the allow list of service %s. Import the entry to manage it", ip, serviceID)
+		}
+		return append(allowList, provisioning.AllowListItem{
+			IPAddress: ip,
+			Comment:   data.Comment.ValueString(),
+		}), nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error adding allow list entry", err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(allowListEntryID(serviceID, ip))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AllowListEntryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *AllowListEntryResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	allowList, err := readAllowList(ctx, r.client, data.ServiceID.ValueString())
+	if err != nil {
+		if errors.Is(err, skysql.ErrorServiceNotFound) {
+			tflog.Warn(ctx, "SkySQL service not found, removing from state", map[string]interface{}{
+				"id": data.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Can not read allow list", err.Error())
+		return
+	}
+
+	i := findAllowListItem(allowList, data.IPAddress.ValueString())
+	if i < 0 {
+		tflog.Warn(ctx, "Allow list entry not found, removing from state", map[string]interface{}{
+			"id": data.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// The address is kept as configured, the API may add the prefix length.
+	if allowList[i].Comment != "" || !data.Comment.IsNull() {
+		data.Comment = types.StringValue(allowList[i].Comment)
+	}
+	data.ID = types.StringValue(allowListEntryID(data.ServiceID.ValueString(), data.IPAddress.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AllowListEntryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *AllowListEntryResourceModel
+	var state *AllowListEntryResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serviceID := state.ServiceID.ValueString()
+	ip := state.IPAddress.ValueString()
+
+	// Only the comment can change in place.
+	if !plan.Comment.Equal(state.Comment) {
+		tflog.Info(ctx, "Updating allow list entry", map[string]interface{}{
+			"service_id": serviceID,
+			"ip":         ip,
+		})
+
+		_, err := modifyAllowList(ctx, r.client, serviceID, updateTimeout, func(allowList []provisioning.AllowListItem) ([]provisioning.AllowListItem, error) {
+			i := findAllowListItem(allowList, ip)
+			if i < 0 {
+				return nil, fmt.Errorf("%s is no longer on the allow list of service %s", ip, serviceID)
+			}
+			allowList[i].Comment = plan.Comment.ValueString()
+			return allowList, nil
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Error updating allow list entry", err.Error())
+			return
+		}
+	}
+
+	state.Comment = plan.Comment
+	state.Timeouts = plan.Timeouts
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *AllowListEntryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *AllowListEntryResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serviceID := data.ServiceID.ValueString()
+	ip := data.IPAddress.ValueString()
+
+	tflog.Info(ctx, "Removing allow list entry", map[string]interface{}{
+		"service_id": serviceID,
+		"ip":         ip,
+	})
+
+	_, err := modifyAllowList(ctx, r.client, serviceID, deleteTimeout, func(allowList []provisioning.AllowListItem) ([]provisioning.AllowListItem, error) {
+		updated := make([]provisioning.AllowListItem, 0, len(allowList))
+		for _, item := range allowList {
+			if !sameAllowListIP(item.IPAddress, ip) {
+				updated = append(updated, item)
+			}
+		}
+		return updated, nil
+	})
+	if err != nil && !errors.Is(err, skysql.ErrorServiceNotFound) {
+		resp.Diagnostics.AddError("Error removing allow list entry", err.Error())
+		return
+	}
+}
+
+func (r *AllowListEntryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	serviceImportID, ip, found := strings.Cut(req.ID, "/")
+	if !found || serviceImportID == "" || ip == "" {
+		resp.Diagnostics.AddError("Can not import resource",
+			fmt.Sprintf("Invalid import ID %q, expected <service_id>/<ip>", req.ID))
+		return
+	}
+	serviceID, err := resolveServiceImportID(ctx, r.client, serviceImportID)
+	if err != nil {
+		resp.Diagnostics.AddError("Can not import resource", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), allowListEntryID(serviceID, ip))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("service_id"), serviceID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("ip"), ip)...)
+}
+
+// allowListEntryID returns the ID of the allow list entry resource.
+func allowListEntryID(serviceID string, ip string) string {
+	return serviceID + "/" + ip
+}
@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql/provisioning"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllowListEntryResource(t *testing.T) {
+	const serviceID = "dbdgf42002418"
+
+	testUrl, expectRequest, close := mockSkySQLAPI(t)
+	defer close()
+	os.Setenv("TF_SKYSQL_API_KEY", "[api-key]")
+	os.Setenv("TF_SKYSQL_API_BASE_URL", testUrl)
+
+	r := require.New(t)
+
+	configureOnce.Reset()
+
+	allowList := []provisioning.AllowListItem{
+		{IPAddress: "10.0.0.0/8", Comment: "owned by another team"},
+	}
+	getAllowList := func(w http.ResponseWriter, req *http.Request) {
+		r.Equal(
+			fmt.Sprintf("%s %s/%s/security/allowlist", http.MethodGet, "/provisioning/v1/services", serviceID),
+			fmt.Sprintf("%s %s", req.Method, req.URL.Path))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(provisioning.ReadAllowListResponse{{AllowList: allowList}})
+	}
+	putAllowList := func(expected []provisioning.AllowListItem) http.HandlerFunc {
+		return func(w http.ResponseWriter, req *http.Request) {
+			r.Equal(
+				fmt.Sprintf("%s %s/%s/security/allowlist", http.MethodPut, "/provisioning/v1/services", serviceID),
+				fmt.Sprintf("%s %s", req.Method, req.URL.Path))
+			var payload []provisioning.AllowListItem
+			r.NoError(json.NewDecoder(req.Body).Decode(&payload))
+			r.Equal(expected, payload)
+			allowList = payload
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(provisioning.ReadAllowListResponse{{AllowList: allowList}})
+		}
+	}
+
+	// Check API connectivity
+	expectRequest(versionsResponse(t))
+	// Add the entry, the first write conflicts with a change made in the
+	// meantime and is applied again to the fresh list
+	expectRequest(getAllowList)
+	expectRequest(func(w http.ResponseWriter, req *http.Request) {
+		r.Equal(http.MethodPut, req.Method)
+		allowList = append(allowList, provisioning.AllowListItem{IPAddress: "172.16.0.0/12", Comment: "added concurrently"})
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(skysql.ErrorResponse{
+			Errors: []skysql.ErrorDetails{{Message: "allow list was modified"}},
+		})
+	})
+	expectRequest(getAllowList)
+	expectRequest(putAllowList([]provisioning.AllowListItem{
+		{IPAddress: "10.0.0.0/8", Comment: "owned by another team"},
+		{IPAddress: "172.16.0.0/12", Comment: "added concurrently"},
+		{IPAddress: "192.158.1.38/32", Comment: "homeoffice"},
+	}))
+	// Refresh state
+	expectRequest(getAllowList)
+	// Refresh before the comment change, which keeps the other entries
+	expectRequest(getAllowList)
+	expectRequest(getAllowList)
+	expectRequest(putAllowList([]provisioning.AllowListItem{
+		{IPAddress: "10.0.0.0/8", Comment: "owned by another team"},
+		{IPAddress: "172.16.0.0/12", Comment: "added concurrently"},
+		{IPAddress: "192.158.1.38/32", Comment: "vpn"},
+	}))
+	expectRequest(getAllowList)
+	// Remove only this entry
+	expectRequest(getAllowList)
+	expectRequest(putAllowList([]provisioning.AllowListItem{
+		{IPAddress: "10.0.0.0/8", Comment: "owned by another team"},
+		{IPAddress: "172.16.0.0/12", Comment: "added concurrently"},
+	}))
+
+	config := func(comment string) string {
+		return fmt.Sprintf(`
+			resource "skysql_allow_list_entry" "office" {
+				service_id = %q
+				ip         = "192.158.1.38/32"
+				comment    = %q
+			}`, serviceID, comment)
+	}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"skysql": providerserver.NewProtocol6WithError(New("")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config("homeoffice"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("skysql_allow_list_entry.office", "id", serviceID+"/192.158.1.38/32"),
+					resource.TestCheckResourceAttr("skysql_allow_list_entry.office", "comment", "homeoffice"),
+				),
+			},
+			{
+				Config: config("vpn"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("skysql_allow_list_entry.office", "comment", "vpn"),
+				),
+			},
+		},
+	})
+}
+
+func TestModifyAllowListSerializesChanges(t *testing.T) {
+	const serviceID = "dbdgf42002418"
+
+	r := require.New(t)
+
+	// The API keeps the last write, changes that are not serialized lose
+	// entries.
+	var mu sync.Mutex
+	var allowList []provisioning.AllowListItem
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodGet {
+			time.Sleep(10 * time.Millisecond)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		if req.Method == http.MethodPut {
+			if err := json.NewDecoder(req.Body).Decode(&allowList); err != nil {
+				t.Errorf("decoding allow list: %v", err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(provisioning.ReadAllowListResponse{{AllowList: allowList}})
+	}))
+	defer srv.Close()
+
+	client := skysql.New(srv.URL, "[api-key]", "")
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ip := fmt.Sprintf("10.0.0.%d/32", i)
+			_, err := modifyAllowList(context.Background(), client, serviceID, time.Minute, func(allowList []provisioning.AllowListItem) ([]provisioning.AllowListItem, error) {
+				return append(allowList, provisioning.AllowListItem{IPAddress: ip}), nil
+			})
+			errs <- err
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		r.NoError(err)
+	}
+
+	r.Len(allowList, 5)
+	for i := 0; i < 5; i++ {
+		r.GreaterOrEqual(findAllowListItem(allowList, fmt.Sprintf("10.0.0.%d", i)), 0)
+	}
+}
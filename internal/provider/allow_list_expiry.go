@@ -0,0 +1,279 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql/provisioning"
+)
+
+// The expiry of an allow list entry is kept at the end of its comment, e.g.
+// "contractor [expires_at=2026-11-01T00:00:00Z]", so it survives reads of the
+// list outside of Terraform.
+const (
+	allowListExpiryPrefix = "[expires_at="
+	allowListExpirySuffix = "]"
+)
+
+// allowListComment appends the expiry to the comment of an entry.
+func allowListComment(comment string, expiresAt string) string {
+	if expiresAt == "" {
+		return comment
+	}
+	expiry := allowListExpiryPrefix + expiresAt + allowListExpirySuffix
+	if comment == "" {
+		return expiry
+	}
+	return comment + " " + expiry
+}
+
+// parseAllowListComment splits the comment of an entry into the comment and
+// the expiry, empty when the entry does not expire.
+func parseAllowListComment(raw string) (string, string) {
+	if !strings.HasSuffix(raw, allowListExpirySuffix) {
+		return raw, ""
+	}
+	i := strings.LastIndex(raw, allowListExpiryPrefix)
+	if i < 0 {
+		return raw, ""
+	}
+	expiresAt := strings.TrimSuffix(raw[i+len(allowListExpiryPrefix):], allowListExpirySuffix)
+	if _, err := time.Parse(time.RFC3339, expiresAt); err != nil {
+		return raw, ""
+	}
+	return strings.TrimSuffix(raw[:i], " "), expiresAt
+}
+
+// allowListItemFromModel converts an allow_list entry to its API
+// representation.
+func allowListItemFromModel(entry AllowListModel) provisioning.AllowListItem {
+	return provisioning.AllowListItem{
+		IPAddress: entry.IPAddress.ValueString(),
+		Comment:   allowListComment(entry.Comment.ValueString(), entry.ExpiresAt.ValueString()),
+	}
+}
+
+// allowListModelFromItem converts an API allow list entry to an allow_list
+// entry.
+func allowListModelFromItem(item provisioning.AllowListItem) AllowListModel {
+	comment, expiresAt := parseAllowListComment(item.Comment)
+	entry := AllowListModel{
+		IPAddress: NewCIDRValue(item.IPAddress),
+		Comment:   types.StringValue(comment),
+		ExpiresAt: types.StringNull(),
+	}
+	if expiresAt != "" {
+		entry.ExpiresAt = types.StringValue(expiresAt)
+	}
+	return entry
+}
+
+// allowListEntryExpired returns whether the entry has expired at now.
+func allowListEntryExpired(entry AllowListModel, now time.Time) bool {
+	if entry.ExpiresAt.IsNull() || entry.ExpiresAt.IsUnknown() {
+		return false
+	}
+	expiresAt, err := time.Parse(time.RFC3339, entry.ExpiresAt.ValueString())
+	return err == nil && !expiresAt.After(now)
+}
+
+// expiredAllowListEntries returns the entries that have expired at now.
+func expiredAllowListEntries(entries []AllowListModel, now time.Time) []AllowListModel {
+	expired := make([]AllowListModel, 0)
+	for _, entry := range entries {
+		if allowListEntryExpired(entry, now) {
+			expired = append(expired, entry)
+		}
+	}
+	return expired
+}
+
+// activeAllowListEntries drops the expired entries from the entries.
+func activeAllowListEntries(entries []AllowListModel, expired []AllowListModel) []AllowListModel {
+	active := make([]AllowListModel, 0, len(entries))
+	for _, entry := range entries {
+		isExpired := false
+		for _, e := range expired {
+			if sameAllowListIP(entry.IPAddress.ValueString(), e.IPAddress.ValueString()) {
+				isExpired = true
+				break
+			}
+		}
+		if !isExpired {
+			active = append(active, entry)
+		}
+	}
+	return active
+}
+
+// plannedExpiredAllowListEntries returns the entries planned to be removed
+// because they expired. When the plan could not tell, the entries that have
+// expired by now are removed.
+func plannedExpiredAllowListEntries(ctx context.Context, planned types.List, entries []AllowListModel) ([]AllowListModel, diag.Diagnostics) {
+	if planned.IsNull() || planned.IsUnknown() {
+		return expiredAllowListEntries(entries, time.Now()), nil
+	}
+	var expired []AllowListModel
+	diags := planned.ElementsAs(ctx, &expired, false)
+	return expired, diags
+}
+
+// restoreExpiredAllowListEntries puts the expired entries of prior that were
+// removed from the service back into the entries read from the API, at their
+// prior position. Expired entries stay in the configuration until they are
+// cleaned up, so their removal is not a difference. It returns the merged
+// entries and the restored expired entries.
+func restoreExpiredAllowListEntries(prior []AllowListModel, remote []AllowListModel, now time.Time) ([]AllowListModel, []AllowListModel) {
+	merged := append(make([]AllowListModel, 0, len(remote)+len(prior)), remote...)
+	expired := make([]AllowListModel, 0)
+	for i, entry := range prior {
+		if !allowListEntryExpired(entry, now) {
+			continue
+		}
+		found := false
+		for _, r := range remote {
+			if sameAllowListIP(entry.IPAddress.ValueString(), r.IPAddress.ValueString()) {
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+		at := i
+		if at > len(merged) {
+			at = len(merged)
+		}
+		merged = append(merged[:at], append([]AllowListModel{entry}, merged[at:]...)...)
+		expired = append(expired, entry)
+	}
+	return merged, expired
+}
+
+// allowListValue converts allow_list entries to a list value.
+func allowListValue(ctx context.Context, entries []AllowListModel) (types.List, diag.Diagnostics) {
+	return types.ListValueFrom(ctx, allowListElementType, entries)
+}
+
+type rfc3339Validator struct{}
+
+// Description returns a plain text description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (v rfc3339Validator) Description(ctx context.Context) string {
+	return "value must be a time in RFC 3339 format, e.g. 2026-11-01T00:00:00Z"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (v rfc3339Validator) MarkdownDescription(ctx context.Context) string {
+	return "value must be a time in RFC 3339 format, e.g. `2026-11-01T00:00:00Z`"
+}
+
+// ValidateString Validate runs the main validation logic of the validator, reading configuration data out of `req` and updating `resp` with diagnostics.
+func (v rfc3339Validator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	if _, err := time.Parse(time.RFC3339, req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid time",
+			fmt.Sprintf("%q must be a time in RFC 3339 format, e.g. 2026-11-01T00:00:00Z: %s", req.ConfigValue.ValueString(), err),
+		)
+	}
+}
+
+// noExpiredAllowListEntries returns an empty list of expired entries. A prior
+// empty or null list is kept, so state written before entries could expire
+// does not show up as a difference.
+func noExpiredAllowListEntries(prior types.List) types.List {
+	if prior.IsNull() {
+		return types.ListNull(allowListElementType)
+	}
+	if !prior.IsUnknown() && len(prior.Elements()) == 0 {
+		return prior
+	}
+	return types.ListValueMust(allowListElementType, []attr.Value{})
+}
+
+// expiredAllowListState returns the list value of the expired entries.
+func expiredAllowListState(ctx context.Context, prior types.List, expired []AllowListModel) (types.List, diag.Diagnostics) {
+	if len(expired) == 0 {
+		return noExpiredAllowListEntries(prior), nil
+	}
+	return allowListValue(ctx, expired)
+}
+
+// setAllowListState reads the allow list of the first endpoint back into the
+// flat allow_list. Expired entries are kept until they are removed from the
+// configuration.
+func (r *ServiceResource) setAllowListState(ctx context.Context, data *ServiceResourceModel, allowList []provisioning.AllowListItem) diag.Diagnostics {
+	if data.IgnoreAllowList.ValueBool() {
+		data.AllowList = types.ListNull(allowListElementType)
+		data.ExpiredAllowList = noExpiredAllowListEntries(data.ExpiredAllowList)
+		return nil
+	}
+
+	list, diags := r.allowListToListType(ctx, allowList)
+	if diags.HasError() {
+		return diags
+	}
+
+	var prior []AllowListModel
+	if !data.AllowList.IsNull() && !data.AllowList.IsUnknown() {
+		diags.Append(data.AllowList.ElementsAs(ctx, &prior, false)...)
+	}
+	var remote []AllowListModel
+	diags.Append(list.ElementsAs(ctx, &remote, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	merged, expired := restoreExpiredAllowListEntries(prior, remote, time.Now())
+	if len(expired) > 0 {
+		var d diag.Diagnostics
+		list, d = allowListValue(ctx, merged)
+		diags.Append(d...)
+	}
+	data.AllowList = list
+
+	var d diag.Diagnostics
+	data.ExpiredAllowList, d = expiredAllowListState(ctx, data.ExpiredAllowList, expired)
+	diags.Append(d...)
+	return diags
+}
+
+// modifyExpiredAllowListPlan plans the removal of the entries of the flat
+// allow_list that have expired, so they show up in the plan even when the
+// configuration did not change.
+func modifyExpiredAllowListPlan(ctx context.Context, plan *ServiceResourceModel, state *ServiceResourceModel, resp *resource.ModifyPlanResponse) {
+	prior := types.ListNull(allowListElementType)
+	if state != nil {
+		prior = state.ExpiredAllowList
+	}
+
+	var expired []AllowListModel
+	if !hasEndpointBlocks(plan) {
+		if plan.AllowList.IsUnknown() {
+			return
+		}
+		var entries []AllowListModel
+		resp.Diagnostics.Append(plan.AllowList.ElementsAs(ctx, &entries, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		expired = expiredAllowListEntries(entries, time.Now())
+	}
+
+	planned, diags := expiredAllowListState(ctx, prior, expired)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("expired_allow_list_entries"), planned)...)
+	plan.ExpiredAllowList = planned
+}
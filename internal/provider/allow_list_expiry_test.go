@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql/provisioning"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllowListComment(t *testing.T) {
+	r := require.New(t)
+
+	for _, tc := range []struct {
+		comment   string
+		expiresAt string
+		raw       string
+	}{
+		{comment: "contractor", expiresAt: "2026-11-01T00:00:00Z", raw: "contractor [expires_at=2026-11-01T00:00:00Z]"},
+		{comment: "", expiresAt: "2026-11-01T00:00:00+02:00", raw: "[expires_at=2026-11-01T00:00:00+02:00]"},
+		{comment: "homeoffice", expiresAt: "", raw: "homeoffice"},
+	} {
+		r.Equal(tc.raw, allowListComment(tc.comment, tc.expiresAt))
+		comment, expiresAt := parseAllowListComment(tc.raw)
+		r.Equal(tc.comment, comment)
+		r.Equal(tc.expiresAt, expiresAt)
+	}
+
+	// Comments that only look like an expiry are kept as they are
+	comment, expiresAt := parseAllowListComment("ticket [expires_at=soon]")
+	r.Equal("ticket [expires_at=soon]", comment)
+	r.Empty(expiresAt)
+}
+
+func TestRestoreExpiredAllowListEntries(t *testing.T) {
+	r := require.New(t)
+
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	entry := func(ip string, expiresAt string) AllowListModel {
+		model := AllowListModel{IPAddress: NewCIDRValue(ip), Comment: types.StringValue(""), ExpiresAt: types.StringNull()}
+		if expiresAt != "" {
+			model.ExpiresAt = types.StringValue(expiresAt)
+		}
+		return model
+	}
+
+	prior := []AllowListModel{
+		entry("10.0.0.1/32", ""),
+		entry("10.0.0.2/32", "2026-10-01T00:00:00Z"),
+		entry("10.0.0.3/32", "2026-12-01T00:00:00Z"),
+		entry("10.0.0.4/32", "2026-10-18T12:00:00Z"),
+	}
+	remote := []AllowListModel{
+		entry("10.0.0.1/32", ""),
+		entry("10.0.0.3/32", "2026-12-01T00:00:00Z"),
+	}
+
+	r.Equal([]AllowListModel{prior[1], prior[3]}, expiredAllowListEntries(prior, now))
+
+	merged, expired := restoreExpiredAllowListEntries(prior, remote, now)
+	r.Equal(prior, merged)
+	r.Equal([]AllowListModel{prior[1], prior[3]}, expired)
+	r.Equal([]AllowListModel{prior[0], prior[2]}, activeAllowListEntries(prior, expired))
+}
+
+func TestSetAllowListStateExpired(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	data := &ServiceAllowListResourceModel{
+		Exclusive:      types.BoolValue(false),
+		ExpiredEntries: types.ListNull(allowListElementType),
+		AllowList: []AllowListModel{
+			{IPAddress: NewCIDRValue("10.0.0.1/32"), Comment: types.StringNull(), ExpiresAt: types.StringValue("2099-01-01T00:00:00Z")},
+			{IPAddress: NewCIDRValue("10.0.0.2/32"), Comment: types.StringValue("contractor"), ExpiresAt: types.StringValue("2020-01-01T00:00:00Z")},
+		},
+	}
+	diags := setAllowListState(ctx, data, []provisioning.AllowListItem{
+		{IPAddress: "10.0.0.1/32", Comment: "[expires_at=2099-01-01T00:00:00Z]"},
+	})
+	r.False(diags.HasError(), "%v", diags)
+
+	// The expired entry was removed and is kept until it is removed from the
+	// configuration
+	r.Equal([]AllowListModel{
+		{IPAddress: NewCIDRValue("10.0.0.1/32"), Comment: types.StringNull(), ExpiresAt: types.StringValue("2099-01-01T00:00:00Z")},
+		{IPAddress: NewCIDRValue("10.0.0.2/32"), Comment: types.StringValue("contractor"), ExpiresAt: types.StringValue("2020-01-01T00:00:00Z")},
+	}, data.AllowList)
+	r.Len(data.ExpiredEntries.Elements(), 1)
+}
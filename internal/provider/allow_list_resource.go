@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
+
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -22,6 +25,7 @@ import (
 var _ resource.Resource = &ServiceAllowListResource{}
 var _ resource.ResourceWithImportState = &ServiceAllowListResource{}
 var _ resource.ResourceWithConfigure = &ServiceAllowListResource{}
+var _ resource.ResourceWithModifyPlan = &ServiceAllowListResource{}
 
 func NewServiceAllowListResource() resource.Resource {
 	return &ServiceAllowListResource{}
@@ -36,13 +40,38 @@ type ServiceAllowListResource struct {
 type ServiceAllowListResourceModel struct {
 	ID              types.String     `tfsdk:"service_id"`
 	AllowList       []AllowListModel `tfsdk:"allow_list"`
+	Exclusive       types.Bool       `tfsdk:"exclusive"`
+	Aggregate       types.Bool       `tfsdk:"aggregate"`
+	AllEntries      types.List       `tfsdk:"all_entries"`
+	ExpiredEntries  types.List       `tfsdk:"expired_entries"`
 	WaitForCreation types.Bool       `tfsdk:"wait_for_creation"`
 	Timeouts        timeouts.Value   `tfsdk:"timeouts"`
 }
 
 type AllowListModel struct {
-	IPAddress types.String `tfsdk:"ip"`
+	IPAddress CIDRValue    `tfsdk:"ip"`
 	Comment   types.String `tfsdk:"comment"`
+	ExpiresAt types.String `tfsdk:"expires_at"`
+}
+
+// allowListComputedNestedObject describes the entries of a computed allow
+// list.
+var allowListComputedNestedObject = schema.NestedAttributeObject{
+	Attributes: map[string]schema.Attribute{
+		"ip": schema.StringAttribute{
+			Computed:    true,
+			CustomType:  CIDRType{},
+			Description: "The IP address in CIDR format",
+		},
+		"comment": schema.StringAttribute{
+			Computed:    true,
+			Description: "The comment of the entry",
+		},
+		"expires_at": schema.StringAttribute{
+			Computed:    true,
+			Description: "The time the entry expires at in RFC 3339 format",
+		},
+	},
 }
 
 func (r *ServiceAllowListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -62,12 +91,16 @@ func (r *ServiceAllowListResource) Schema(ctx context.Context, req resource.Sche
 				}},
 			"allow_list": schema.ListNestedAttribute{
 				Required:    true,
-				Description: "The list of IP addresses with comments to allow access to the service",
+				Description: "The list of IP addresses with comments to allow access to the service. Duplicate and overlapping entries are reported as warnings",
+				Validators: []validator.List{
+					allowListOverlapValidator{aggregate: "aggregate"},
+				},
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"ip": schema.StringAttribute{
 							Required:    true,
-							Description: "The IP address to allow access to the service. The IP must be in a valid CIDR format",
+							CustomType:  CIDRType{},
+							Description: "The IP address to allow access to the service. The IP must be an IPv4 or IPv6 address or a range in CIDR format",
 							Validators: []validator.String{
 								allowListIPValidator{},
 							},
@@ -76,9 +109,45 @@ func (r *ServiceAllowListResource) Schema(ctx context.Context, req resource.Sche
 							Optional:    true,
 							Description: "A comment to describe the IP address",
 						},
+						"expires_at": schema.StringAttribute{
+							Optional: true,
+							Description: "The time the entry expires at in RFC 3339 format, e.g. 2026-11-01T00:00:00Z. Expired entries are removed " +
+								"from the service on the next apply. The expiry is kept in the comment of the entry",
+							Validators: []validator.String{
+								rfc3339Validator{},
+							},
+						},
 					},
 				},
 			},
+			"exclusive": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Description: "Whether allow_list is the whole allow list of the service. When false, only the entries declared in allow_list are managed " +
+					"and entries added outside of Terraform are left unchanged. Default is true",
+				PlanModifiers: []planmodifier.Bool{
+					boolDefault(true),
+				},
+			},
+			"aggregate": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Description: "Whether duplicate entries and entries contained in a wider entry of allow_list are collapsed into the wider entry " +
+					"before the list is written. The collapsed entries stay in allow_list as long as the wider entry covers them. Default is false",
+				PlanModifiers: []planmodifier.Bool{
+					boolDefault(false),
+				},
+			},
+			"all_entries": schema.ListNestedAttribute{
+				Computed:     true,
+				Description:  "All entries of the allow list of the service, including the entries that are not managed by this resource",
+				NestedObject: allowListComputedNestedObject,
+			},
+			"expired_entries": schema.ListNestedAttribute{
+				Computed:     true,
+				Description:  "The entries of allow_list that have expired and are removed from the service",
+				NestedObject: allowListComputedNestedObject,
+			},
 			"wait_for_creation": schema.BoolAttribute{
 				Optional:    true,
 				Description: "If true, the provider will wait for the service to be updated before returning. ",
@@ -122,22 +191,15 @@ func (r *ServiceAllowListResource) Create(ctx context.Context, req resource.Crea
 		return
 	}
 
-	allowListUpdateRequest := make([]provisioning.AllowListItem, len(data.AllowList))
-	for i := range data.AllowList {
-		allowListUpdateRequest[i].IPAddress = data.AllowList[i].IPAddress.ValueString()
-		allowListUpdateRequest[i].Comment = data.AllowList[i].Comment.ValueString()
-	}
-
-	allowListResp, err := r.client.UpdateServiceAllowListByID(ctx, data.ID.ValueString(), allowListUpdateRequest)
+	allowListResp, err := r.writeAllowList(ctx, data, nil, data.AllowList, defaultCreateTimeout)
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating service allow list", err.Error())
 		return
 	}
 
-	data.AllowList = make([]AllowListModel, len(allowListResp))
-	for i := range allowListResp {
-		data.AllowList[i].IPAddress = types.StringValue(allowListResp[i].IPAddress)
-		data.AllowList[i].Comment = types.StringValue(allowListResp[i].Comment)
+	resp.Diagnostics.Append(setAllowListState(ctx, data, allowListResp)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// save into the Terraform state.
@@ -187,22 +249,15 @@ func (r *ServiceAllowListResource) Read(ctx context.Context, req resource.ReadRe
 		return
 	}
 
-	allowListResp, err := r.client.ReadServiceAllowListByID(ctx, data.ID.ValueString())
+	allowListResp, err := readAllowList(ctx, r.client, data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Can not find service", err.Error())
 		return
 	}
 
-	if len(allowListResp) == 0 {
-		data.AllowList = make([]AllowListModel, 0)
-	} else {
-		data.AllowList = make([]AllowListModel, len(allowListResp[0].AllowList))
-		if len(allowListResp[0].AllowList) > 0 {
-			for i := range allowListResp[0].AllowList {
-				data.AllowList[i].IPAddress = types.StringValue(allowListResp[0].AllowList[i].IPAddress)
-				data.AllowList[i].Comment = types.StringValue(allowListResp[0].AllowList[i].Comment)
-			}
-		}
+	resp.Diagnostics.Append(setAllowListState(ctx, data, allowListResp)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -234,13 +289,7 @@ func (r *ServiceAllowListResource) Update(ctx context.Context, req resource.Upda
 		return
 	}
 
-	allowListUpdateRequest := make([]provisioning.AllowListItem, len(plan.AllowList))
-	for i := range plan.AllowList {
-		allowListUpdateRequest[i].IPAddress = plan.AllowList[i].IPAddress.ValueString()
-		allowListUpdateRequest[i].Comment = plan.AllowList[i].Comment.ValueString()
-	}
-
-	allowListResp, err := r.client.UpdateServiceAllowListByID(ctx, plan.ID.ValueString(), allowListUpdateRequest)
+	allowListResp, err := r.writeAllowList(ctx, plan, state.AllowList, plan.AllowList, defaultUpdateTimeout)
 	if err != nil {
 		if errors.Is(err, skysql.ErrorServiceNotFound) {
 			tflog.Warn(ctx, "SkySQL service not found, removing from state", map[string]interface{}{
@@ -254,10 +303,12 @@ func (r *ServiceAllowListResource) Update(ctx context.Context, req resource.Upda
 		return
 	}
 
-	state.AllowList = make([]AllowListModel, len(allowListResp))
-	for i := range allowListResp {
-		state.AllowList[i].IPAddress = types.StringValue(allowListResp[i].IPAddress)
-		state.AllowList[i].Comment = types.StringValue(allowListResp[i].Comment)
+	state.AllowList = plan.AllowList
+	state.Exclusive = plan.Exclusive
+	state.Aggregate = plan.Aggregate
+	resp.Diagnostics.Append(setAllowListState(ctx, state, allowListResp)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// save into the Terraform state.
@@ -307,9 +358,7 @@ func (r *ServiceAllowListResource) Delete(ctx context.Context, req resource.Dele
 		return
 	}
 
-	allowListUpdateRequest := make([]provisioning.AllowListItem, 0)
-
-	_, err := r.client.UpdateServiceAllowListByID(ctx, data.ID.ValueString(), allowListUpdateRequest)
+	_, err := r.writeAllowList(ctx, data, data.AllowList, nil, defaultDeleteTimeout)
 	if err != nil {
 		if errors.Is(err, skysql.ErrorServiceNotFound) {
 			tflog.Warn(ctx, "SkySQL service not found, removing from state", map[string]interface{}{
@@ -362,6 +411,221 @@ func (r *ServiceAllowListResource) Delete(ctx context.Context, req resource.Dele
 	}
 }
 
+func (r *ServiceAllowListResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var serviceID types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("service_id"), &serviceID)...)
+	if !serviceID.IsUnknown() && !serviceID.IsNull() &&
+		Contains[string](claimAllowList(serviceID.ValueString(), allowListOwnerAllowList), allowListOwnerService) {
+		summary, detail := allowListOwnerConflict(serviceID.ValueString())
+		resp.Diagnostics.AddAttributeWarning(path.Root("service_id"), summary, detail)
+	}
+
+	var allowList types.List
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("allow_list"), &allowList)...)
+	if resp.Diagnostics.HasError() || allowList.IsUnknown() {
+		return
+	}
+
+	var entries []AllowListModel
+	resp.Diagnostics.Append(allowList.ElementsAs(ctx, &entries, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prior := types.ListNull(allowListElementType)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("expired_entries"), &prior)...)
+	}
+
+	// Expired entries are planned for removal, so they show up in the plan
+	// even when the configuration did not change.
+	expired, diags := expiredAllowListState(ctx, prior, expiredAllowListEntries(entries, time.Now()))
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("expired_entries"), expired)...)
+}
+
 func (r *ServiceAllowListResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	id, err := resolveServiceImportID(ctx, r.client, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Can not import resource", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("service_id"), id)...)
+}
+
+// allowListExclusive returns whether the resource owns the whole allow list.
+// State written before exclusive was added has no value and is exclusive.
+func allowListExclusive(data *ServiceAllowListResourceModel) bool {
+	return data.Exclusive.IsNull() || data.Exclusive.IsUnknown() || data.Exclusive.ValueBool()
+}
+
+// writeAllowList writes the planned entries to the allow list of the service.
+// An exclusive list replaces the list of the service. Otherwise only the
+// entries of prior that are no longer planned are removed, and the entries
+// added outside of Terraform are kept. Expired entries are not written. With
+// aggregate, overlapping planned entries are collapsed first.
+func (r *ServiceAllowListResource) writeAllowList(ctx context.Context, data *ServiceAllowListResourceModel, prior []AllowListModel, plan []AllowListModel, timeout time.Duration) ([]provisioning.AllowListItem, error) {
+	serviceID := data.ID.ValueString()
+	expired, diags := plannedExpiredAllowListEntries(ctx, data.ExpiredEntries, plan)
+	if diags.HasError() {
+		return nil, fmt.Errorf("can not read expired entries: %v", diags)
+	}
+	plan = activeAllowListEntries(plan, expired)
+	if data.Aggregate.ValueBool() {
+		plan = aggregateAllowListModels(plan)
+	}
+
+	if allowListExclusive(data) {
+		allowListUpdateRequest := make([]provisioning.AllowListItem, len(plan))
+		for i := range plan {
+			allowListUpdateRequest[i] = allowListItemFromModel(plan[i])
+		}
+
+		unlock := lockAllowList(serviceID)
+		defer unlock()
+		return r.client.UpdateServiceAllowListByID(ctx, serviceID, allowListUpdateRequest)
+	}
+
+	return modifyAllowList(ctx, r.client, serviceID, timeout, func(allowList []provisioning.AllowListItem) ([]provisioning.AllowListItem, error) {
+		// Expired entries are removed even when they were added outside of
+		// Terraform.
+		return mergeAllowList(allowList, append(append([]AllowListModel{}, prior...), expired...), plan), nil
+	})
+}
+
+// aggregateAllowListModels drops the entries that are collapsed into a wider
+// entry.
+func aggregateAllowListModels(entries []AllowListModel) []AllowListModel {
+	ips := make([]string, len(entries))
+	for i := range entries {
+		ips[i] = entries[i].IPAddress.ValueString()
+	}
+	collapsed := collapsedAllowListEntries(ips)
+
+	aggregated := make([]AllowListModel, 0, len(entries))
+	for i := range entries {
+		if !collapsed[i] {
+			aggregated = append(aggregated, entries[i])
+		}
+	}
+	return aggregated
+}
+
+// mergeAllowList removes the entries of prior that are not planned anymore
+// from the allow list and adds or updates the planned entries. Other entries
+// are kept.
+func mergeAllowList(allowList []provisioning.AllowListItem, prior []AllowListModel, plan []AllowListModel) []provisioning.AllowListItem {
+	planned := func(ip string) bool {
+		for i := range plan {
+			if sameAllowListIP(plan[i].IPAddress.ValueString(), ip) {
+				return true
+			}
+		}
+		return false
+	}
+
+	merged := make([]provisioning.AllowListItem, 0, len(allowList)+len(plan))
+	for _, item := range allowList {
+		removed := false
+		for i := range prior {
+			if sameAllowListIP(prior[i].IPAddress.ValueString(), item.IPAddress) && !planned(item.IPAddress) {
+				removed = true
+				break
+			}
+		}
+		if !removed {
+			merged = append(merged, item)
+		}
+	}
+
+	for i := range plan {
+		item := allowListItemFromModel(plan[i])
+		if j := findAllowListItem(merged, item.IPAddress); j >= 0 {
+			merged[j] = item
+		} else {
+			merged = append(merged, item)
+		}
+	}
+	return merged
+}
+
+// setAllowListState reads the allow list of the service back into the model.
+// An exclusive resource tracks the whole list, otherwise only the entries
+// already in allow_list are tracked and dropped once they are removed outside
+// of Terraform. With aggregate, an entry is kept while an entry of the list
+// covers it. Expired entries are kept until they are removed from the
+// configuration.
+func setAllowListState(ctx context.Context, data *ServiceAllowListResourceModel, allowList []provisioning.AllowListItem) diag.Diagnostics {
+	exclusive := allowListExclusive(data)
+	aggregate := data.Aggregate.ValueBool()
+	data.Exclusive = types.BoolValue(exclusive)
+	data.Aggregate = types.BoolValue(aggregate)
+
+	allEntries := make([]AllowListModel, len(allowList))
+	for i := range allowList {
+		allEntries[i] = allowListModelFromItem(allowList[i])
+	}
+
+	var diags diag.Diagnostics
+	data.AllEntries, diags = allowListValue(ctx, allEntries)
+
+	now := time.Now()
+	if exclusive && !aggregate {
+		var expired []AllowListModel
+		data.AllowList, expired = restoreExpiredAllowListEntries(data.AllowList, allEntries, now)
+		expiredEntries, d := expiredAllowListState(ctx, data.ExpiredEntries, expired)
+		diags.Append(d...)
+		data.ExpiredEntries = expiredEntries
+		return diags
+	}
+
+	tracked := make([]AllowListModel, 0, len(data.AllowList))
+	expired := make([]AllowListModel, 0)
+	for _, entry := range data.AllowList {
+		i := findAllowListItem(allowList, entry.IPAddress.ValueString())
+		if i < 0 {
+			if allowListEntryExpired(entry, now) {
+				tracked = append(tracked, entry)
+				expired = append(expired, entry)
+			} else if aggregate && allowListCovered(allowList, entry.IPAddress.ValueString()) {
+				tracked = append(tracked, entry)
+			}
+			continue
+		}
+		if allEntries[i].Comment.ValueString() != "" || !entry.Comment.IsNull() {
+			entry.Comment = allEntries[i].Comment
+		}
+		entry.ExpiresAt = allEntries[i].ExpiresAt
+		tracked = append(tracked, entry)
+	}
+
+	if exclusive {
+		// Entries added outside of Terraform show up as a difference.
+		for i, item := range allowList {
+			if !allowListCoversAny(item, tracked) {
+				tracked = append(tracked, allEntries[i])
+			}
+		}
+	}
+
+	data.AllowList = tracked
+	expiredEntries, d := expiredAllowListState(ctx, data.ExpiredEntries, expired)
+	diags.Append(d...)
+	data.ExpiredEntries = expiredEntries
+	return diags
+}
+
+// allowListCoversAny returns whether item covers one of the entries.
+func allowListCoversAny(item provisioning.AllowListItem, entries []AllowListModel) bool {
+	for i := range entries {
+		if allowListCovered([]provisioning.AllowListItem{item}, entries[i].IPAddress.ValueString()) {
+			return true
+		}
+	}
+	return false
 }
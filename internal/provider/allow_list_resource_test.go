@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql/provisioning"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceAllowListResourceNonExclusive(t *testing.T) {
+	const serviceID = "dbdgf42002418"
+
+	testUrl, expectRequest, close := mockSkySQLAPI(t)
+	defer close()
+	os.Setenv("TF_SKYSQL_API_KEY", "[api-key]")
+	os.Setenv("TF_SKYSQL_API_BASE_URL", testUrl)
+
+	r := require.New(t)
+
+	configureOnce.Reset()
+
+	allowList := []provisioning.AllowListItem{
+		{IPAddress: "198.51.100.7/32", Comment: "incident 4711"},
+	}
+	getAllowList := func(w http.ResponseWriter, req *http.Request) {
+		r.Equal(
+			fmt.Sprintf("%s %s/%s/security/allowlist", http.MethodGet, "/provisioning/v1/services", serviceID),
+			fmt.Sprintf("%s %s", req.Method, req.URL.Path))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(provisioning.ReadAllowListResponse{{AllowList: allowList}})
+	}
+	putAllowList := func(expected []provisioning.AllowListItem) http.HandlerFunc {
+		return func(w http.ResponseWriter, req *http.Request) {
+			r.Equal(
+				fmt.Sprintf("%s %s/%s/security/allowlist", http.MethodPut, "/provisioning/v1/services", serviceID),
+				fmt.Sprintf("%s %s", req.Method, req.URL.Path))
+			var payload []provisioning.AllowListItem
+			r.NoError(json.NewDecoder(req.Body).Decode(&payload))
+			r.Equal(expected, payload)
+			allowList = payload
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(provisioning.ReadAllowListResponse{{AllowList: allowList}})
+		}
+	}
+
+	// Check API connectivity
+	expectRequest(versionsResponse(t))
+	// Add the declared entries next to the entry added in the portal
+	expectRequest(getAllowList)
+	expectRequest(putAllowList([]provisioning.AllowListItem{
+		{IPAddress: "198.51.100.7/32", Comment: "incident 4711"},
+		{IPAddress: "192.158.1.38/32", Comment: "homeoffice"},
+	}))
+	// Refresh state
+	expectRequest(getAllowList)
+	// Remove only the declared entries
+	expectRequest(getAllowList)
+	expectRequest(putAllowList([]provisioning.AllowListItem{
+		{IPAddress: "198.51.100.7/32", Comment: "incident 4711"},
+	}))
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"skysql": providerserver.NewProtocol6WithError(New("")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "skysql_allow_list" "default" {
+						service_id = %q
+						exclusive  = false
+						allow_list = [
+							{
+								ip      = "192.158.1.38/32"
+								comment = "homeoffice"
+							}
+						]
+					}`, serviceID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("skysql_allow_list.default", "allow_list.#", "1"),
+					resource.TestCheckResourceAttr("skysql_allow_list.default", "all_entries.#", "2"),
+					resource.TestCheckResourceAttr("skysql_allow_list.default", "all_entries.0.ip", "198.51.100.7/32"),
+				),
+			},
+		},
+	})
+}
+
+func TestMergeAllowList(t *testing.T) {
+	r := require.New(t)
+
+	entry := func(ip string, comment string) AllowListModel {
+		return AllowListModel{IPAddress: NewCIDRValue(ip), Comment: types.StringValue(comment)}
+	}
+
+	merged := mergeAllowList(
+		[]provisioning.AllowListItem{
+			{IPAddress: "10.0.0.1/32", Comment: "managed"},
+			{IPAddress: "10.0.0.2/32", Comment: "removed"},
+			{IPAddress: "10.0.0.3/32", Comment: "added in the portal"},
+		},
+		[]AllowListModel{entry("10.0.0.1", "managed"), entry("10.0.0.2/32", "removed")},
+		[]AllowListModel{entry("10.0.0.1/32", "renamed"), entry("10.0.0.4/32", "new")},
+	)
+	r.Equal([]provisioning.AllowListItem{
+		{IPAddress: "10.0.0.1/32", Comment: "renamed"},
+		{IPAddress: "10.0.0.3/32", Comment: "added in the portal"},
+		{IPAddress: "10.0.0.4/32", Comment: "new"},
+	}, merged)
+}
+
+func TestSetAllowListStateNonExclusive(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	data := &ServiceAllowListResourceModel{
+		Exclusive: types.BoolValue(false),
+		AllowList: []AllowListModel{
+			{IPAddress: NewCIDRValue("10.0.0.1/32"), Comment: types.StringNull()},
+			{IPAddress: NewCIDRValue("10.0.0.2/32"), Comment: types.StringValue("removed in the portal")},
+		},
+	}
+	diags := setAllowListState(ctx, data, []provisioning.AllowListItem{
+		{IPAddress: "10.0.0.1/32"},
+		{IPAddress: "10.0.0.3/32", Comment: "added in the portal"},
+	})
+	r.False(diags.HasError(), "%v", diags)
+
+	r.Equal([]AllowListModel{
+		{IPAddress: NewCIDRValue("10.0.0.1/32"), Comment: types.StringNull()},
+	}, data.AllowList)
+	r.Len(data.AllEntries.Elements(), 2)
+}
+
+func TestSetAllowListStateAggregate(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	data := &ServiceAllowListResourceModel{
+		Exclusive: types.BoolValue(true),
+		Aggregate: types.BoolValue(true),
+		AllowList: []AllowListModel{
+			{IPAddress: NewCIDRValue("10.0.0.0/16"), Comment: types.StringValue("office")},
+			{IPAddress: NewCIDRValue("10.0.1.7"), Comment: types.StringValue("jump host")},
+		},
+	}
+	diags := setAllowListState(ctx, data, []provisioning.AllowListItem{
+		{IPAddress: "10.0.0.0/16", Comment: "office"},
+		{IPAddress: "198.51.100.7/32", Comment: "added in the portal"},
+	})
+	r.False(diags.HasError(), "%v", diags)
+
+	// The collapsed entry is kept, the foreign entry shows up as a difference
+	r.Equal([]AllowListModel{
+		{IPAddress: NewCIDRValue("10.0.0.0/16"), Comment: types.StringValue("office")},
+		{IPAddress: NewCIDRValue("10.0.1.7"), Comment: types.StringValue("jump host")},
+		{IPAddress: NewCIDRValue("198.51.100.7/32"), Comment: types.StringValue("added in the portal")},
+	}, data.AllowList)
+}
@@ -0,0 +1,515 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql/provisioning"
+)
+
+// allowListSetParallelism is the number of services whose allow list is
+// written at the same time. Requests beyond the rate limit of the API are
+// retried by the client.
+const allowListSetParallelism = 4
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &AllowListSetResource{}
+var _ resource.ResourceWithConfigure = &AllowListSetResource{}
+var _ resource.ResourceWithModifyPlan = &AllowListSetResource{}
+
+func NewAllowListSetResource() resource.Resource {
+	return &AllowListSetResource{}
+}
+
+// AllowListSetResource defines the resource implementation.
+type AllowListSetResource struct {
+	client *skysql.Client
+}
+
+// AllowListSetResourceModel describes the resource data model.
+type AllowListSetResourceModel struct {
+	ID         types.String             `tfsdk:"id"`
+	ServiceIDs types.Set                `tfsdk:"service_ids"`
+	Entries    []AllowListSetEntryModel `tfsdk:"entries"`
+	Results    types.List               `tfsdk:"results"`
+	Timeouts   timeouts.Value           `tfsdk:"timeouts"`
+}
+
+// AllowListSetEntryModel is an entry applied to every service of the set.
+type AllowListSetEntryModel struct {
+	IPAddress CIDRValue    `tfsdk:"ip"`
+	Comment   types.String `tfsdk:"comment"`
+}
+
+// AllowListSetResultModel is the outcome of applying the entries to a service.
+type AllowListSetResultModel struct {
+	ServiceID types.String `tfsdk:"service_id"`
+	Success   types.Bool   `tfsdk:"success"`
+	Error     types.String `tfsdk:"error"`
+}
+
+var allowListSetResultElementType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"service_id": types.StringType,
+		"success":    types.BoolType,
+		"error":      types.StringType,
+	},
+}
+
+func (r *AllowListSetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_allow_list_set"
+}
+
+func (r *AllowListSetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Applies the same allow list entries to several services. The services are updated concurrently, " +
+			"a service that fails does not stop the others and is retried on the next apply. Other entries of the allow lists are left unchanged",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the allow list set",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"service_ids": schema.SetAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Description: "The IDs of the services to apply the entries to",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"entries": schema.ListNestedAttribute{
+				Required:    true,
+				Description: "The list of IP addresses with comments to allow access to the services. Duplicate and overlapping entries are reported as warnings",
+				Validators: []validator.List{
+					allowListOverlapValidator{},
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"ip": schema.StringAttribute{
+							Required:    true,
+							CustomType:  CIDRType{},
+							Description: "The IP address to allow access to the services. The IP must be an IPv4 or IPv6 address or a range in CIDR format",
+							Validators: []validator.String{
+								allowListIPValidator{},
+							},
+						},
+						"comment": schema.StringAttribute{
+							Optional:    true,
+							Description: "A comment to describe the IP address",
+						},
+					},
+				},
+			},
+			"results": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The outcome of applying the entries to each service, ordered by service ID",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"service_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The ID of the service",
+						},
+						"success": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the service has all entries",
+						},
+						"error": schema.StringAttribute{
+							Computed:    true,
+							Description: "Why the entries could not be applied to the service",
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *AllowListSetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*skysql.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *skysql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *AllowListSetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *AllowListSetResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serviceIDs, diags := allowListSetServiceIDs(ctx, data.ServiceIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	errs := r.applyAllowListSet(ctx, serviceIDs, nil, data.Entries, createTimeout)
+
+	data.ID = types.StringValue(uuid.New().String())
+	resp.Diagnostics.Append(setAllowListSetResults(ctx, data, serviceIDs, errs)...)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AllowListSetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *AllowListSetResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serviceIDs, diags := allowListSetServiceIDs(ctx, data.ServiceIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A service that is missing entries is reported as failed, so the next
+	// plan applies the entries to it again.
+	errs := forEachService(ctx, serviceIDs, func(ctx context.Context, serviceID string) error {
+		allowList, err := readAllowList(ctx, r.client, serviceID)
+		if err != nil {
+			return err
+		}
+		return allowListSetMissing(allowList, data.Entries)
+	})
+
+	results, diags := allowListSetResults(ctx, serviceIDs, errs)
+	resp.Diagnostics.Append(diags...)
+	data.Results = results
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AllowListSetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on create and destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var results types.List
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("results"), &results)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Services that failed are retried, even when the configuration did not
+	// change.
+	if len(failedAllowListSetServices(ctx, results)) > 0 {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("results"), types.ListUnknown(allowListSetResultElementType))...)
+	}
+}
+
+func (r *AllowListSetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *AllowListSetResourceModel
+	var state *AllowListSetResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planned, diags := allowListSetServiceIDs(ctx, plan.ServiceIDs)
+	resp.Diagnostics.Append(diags...)
+	prior, diags := allowListSetServiceIDs(ctx, state.ServiceIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Services that are no longer in the set lose the entries of the set.
+	removed := make([]string, 0)
+	for _, serviceID := range prior {
+		if !Contains[string](planned, serviceID) {
+			removed = append(removed, serviceID)
+		}
+	}
+	for serviceID, err := range r.applyAllowListSet(ctx, removed, state.Entries, nil, updateTimeout) {
+		if err != nil && !errors.Is(err, skysql.ErrorServiceNotFound) {
+			resp.Diagnostics.AddError("Error removing allow list entries",
+				fmt.Sprintf("Unable to remove the entries from service %s: %s", serviceID, err))
+		}
+	}
+	// The prior state keeps the services in the set, so the removal is
+	// retried on the next apply instead of leaking their entries.
+	if resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
+	// Unchanged entries are only applied to new services and services that
+	// failed before.
+	apply := planned
+	if allowListSetEntriesEqual(plan.Entries, state.Entries) {
+		failed := failedAllowListSetServices(ctx, state.Results)
+		apply = make([]string, 0)
+		for _, serviceID := range planned {
+			if failed[serviceID] || !Contains[string](prior, serviceID) {
+				apply = append(apply, serviceID)
+			}
+		}
+	}
+
+	tflog.Info(ctx, "Updating allow list set", map[string]interface{}{
+		"id":       state.ID.ValueString(),
+		"services": strings.Join(apply, ","),
+	})
+
+	errs := r.applyAllowListSet(ctx, apply, state.Entries, plan.Entries, updateTimeout)
+	for _, serviceID := range planned {
+		if _, ok := errs[serviceID]; !ok {
+			errs[serviceID] = nil
+		}
+	}
+
+	plan.ID = state.ID
+	resp.Diagnostics.Append(setAllowListSetResults(ctx, plan, planned, errs)...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *AllowListSetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *AllowListSetResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serviceIDs, diags := allowListSetServiceIDs(ctx, data.ServiceIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for serviceID, err := range r.applyAllowListSet(ctx, serviceIDs, data.Entries, nil, deleteTimeout) {
+		if err != nil && !errors.Is(err, skysql.ErrorServiceNotFound) {
+			resp.Diagnostics.AddError("Error removing allow list entries",
+				fmt.Sprintf("Unable to remove the entries from service %s: %s", serviceID, err))
+		}
+	}
+}
+
+// applyAllowListSet replaces the prior entries with the planned entries on
+// each service. It returns the outcome per service.
+func (r *AllowListSetResource) applyAllowListSet(ctx context.Context, serviceIDs []string, prior []AllowListSetEntryModel, plan []AllowListSetEntryModel, timeout time.Duration) map[string]error {
+	priorModels := allowListSetModels(prior)
+	planModels := allowListSetModels(plan)
+	return forEachService(ctx, serviceIDs, func(ctx context.Context, serviceID string) error {
+		tflog.Debug(ctx, "Applying allow list set", map[string]interface{}{
+			"service_id": serviceID,
+		})
+		_, err := modifyAllowList(ctx, r.client, serviceID, timeout, func(allowList []provisioning.AllowListItem) ([]provisioning.AllowListItem, error) {
+			return mergeAllowList(allowList, priorModels, planModels), nil
+		})
+		return err
+	})
+}
+
+// forEachService runs fn for each service, at most allowListSetParallelism at
+// a time. A failing service does not stop the others.
+func forEachService(ctx context.Context, serviceIDs []string, fn func(ctx context.Context, serviceID string) error) map[string]error {
+	errs := make(map[string]error, len(serviceIDs))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, allowListSetParallelism)
+	for _, serviceID := range serviceIDs {
+		wg.Add(1)
+		go func(serviceID string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			err := fn(ctx, serviceID)
+			mu.Lock()
+			errs[serviceID] = err
+			mu.Unlock()
+		}(serviceID)
+	}
+	wg.Wait()
+	return errs
+}
+
+// allowListSetServiceIDs returns the sorted service IDs of the set.
+func allowListSetServiceIDs(ctx context.Context, set types.Set) ([]string, diag.Diagnostics) {
+	serviceIDs := make([]string, 0, len(set.Elements()))
+	if set.IsNull() || set.IsUnknown() {
+		return serviceIDs, nil
+	}
+	diags := set.ElementsAs(ctx, &serviceIDs, false)
+	sort.Strings(serviceIDs)
+	return serviceIDs, diags
+}
+
+// allowListSetModels converts the entries of the set to allow_list entries.
+func allowListSetModels(entries []AllowListSetEntryModel) []AllowListModel {
+	models := make([]AllowListModel, len(entries))
+	for i := range entries {
+		models[i] = AllowListModel{
+			IPAddress: entries[i].IPAddress,
+			Comment:   entries[i].Comment,
+			ExpiresAt: types.StringNull(),
+		}
+	}
+	return models
+}
+
+// allowListSetEntriesEqual returns whether both lists have the same entries.
+func allowListSetEntriesEqual(a []AllowListSetEntryModel, b []AllowListSetEntryModel) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !sameAllowListIP(a[i].IPAddress.ValueString(), b[i].IPAddress.ValueString()) ||
+			a[i].Comment.ValueString() != b[i].Comment.ValueString() {
+			return false
+		}
+	}
+	return true
+}
+
+// allowListSetMissing returns an error naming the entries that are missing
+// from the allow list or have a different comment.
+func allowListSetMissing(allowList []provisioning.AllowListItem, entries []AllowListSetEntryModel) error {
+	missing := make([]string, 0)
+	for _, entry := range entries {
+		i := findAllowListItem(allowList, entry.IPAddress.ValueString())
+		if i < 0 || allowList[i].Comment != entry.Comment.ValueString() {
+			missing = append(missing, entry.IPAddress.ValueString())
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("entries missing or changed outside of Terraform: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// allowListSetResults converts the outcome per service to the results
+// attribute.
+func allowListSetResults(ctx context.Context, serviceIDs []string, errs map[string]error) (types.List, diag.Diagnostics) {
+	results := make([]AllowListSetResultModel, 0, len(serviceIDs))
+	for _, serviceID := range serviceIDs {
+		result := AllowListSetResultModel{
+			ServiceID: types.StringValue(serviceID),
+			Success:   types.BoolValue(true),
+			Error:     types.StringNull(),
+		}
+		if err := errs[serviceID]; err != nil {
+			result.Success = types.BoolValue(false)
+			result.Error = types.StringValue(err.Error())
+		}
+		results = append(results, result)
+	}
+	return types.ListValueFrom(ctx, allowListSetResultElementType, results)
+}
+
+// setAllowListSetResults records the outcome per service and reports a
+// warning for each service the entries could not be applied to. The failed
+// services are retried through the results, an error would taint a new set
+// and replacing it removes the entries from every service. Only a set that
+// could not be applied to any service fails.
+func setAllowListSetResults(ctx context.Context, data *AllowListSetResourceModel, serviceIDs []string, errs map[string]error) diag.Diagnostics {
+	results, diags := allowListSetResults(ctx, serviceIDs, errs)
+	data.Results = results
+	failed := 0
+	for _, serviceID := range serviceIDs {
+		if err := errs[serviceID]; err != nil {
+			failed++
+			diags.AddWarning("Error applying allow list",
+				fmt.Sprintf("Unable to apply the entries to service %s, it is retried on the next apply: %s", serviceID, err))
+		}
+	}
+	if failed > 0 && failed == len(serviceIDs) {
+		diags.AddError("Error applying allow list", "Unable to apply the entries to any of the services.")
+	}
+	return diags
+}
+
+// failedAllowListSetServices returns the services of the results that the
+// entries could not be applied to.
+func failedAllowListSetServices(ctx context.Context, results types.List) map[string]bool {
+	failed := make(map[string]bool)
+	if results.IsNull() || results.IsUnknown() {
+		return failed
+	}
+	var models []AllowListSetResultModel
+	if results.ElementsAs(ctx, &models, false).HasError() {
+		return failed
+	}
+	for _, result := range models {
+		if !result.Success.ValueBool() {
+			failed[result.ServiceID.ValueString()] = true
+		}
+	}
+	return failed
+}
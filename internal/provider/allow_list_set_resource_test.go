@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql/provisioning"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyAllowListSetContinuesOnFailure(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	// The second service rejects the write, the others keep their entries
+	// added outside of Terraform.
+	var mu sync.Mutex
+	allowLists := map[string][]provisioning.AllowListItem{
+		"service-a": {{IPAddress: "10.0.0.1/32", Comment: "portal"}},
+		"service-b": {},
+		"service-c": {},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		serviceID := strings.Split(strings.TrimPrefix(req.URL.Path, "/provisioning/v1/services/"), "/")[0]
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		if req.Method == http.MethodPut {
+			if serviceID == "service-b" {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(skysql.ErrorResponse{
+					Errors: []skysql.ErrorDetails{{Message: "service is not ready"}},
+				})
+				return
+			}
+			// A bad payload fails the write, so the test goroutine sees it
+			var payload []provisioning.AllowListItem
+			if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(skysql.ErrorResponse{
+					Errors: []skysql.ErrorDetails{{Message: err.Error()}},
+				})
+				return
+			}
+			allowLists[serviceID] = payload
+		}
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(provisioning.ReadAllowListResponse{{AllowList: allowLists[serviceID]}})
+	}))
+	defer srv.Close()
+
+	res := &AllowListSetResource{client: skysql.New(srv.URL, "[api-key]", "")}
+	entries := []AllowListSetEntryModel{
+		{IPAddress: NewCIDRValue("192.158.1.38/32"), Comment: types.StringValue("vpn")},
+	}
+	serviceIDs := []string{"service-a", "service-b", "service-c"}
+
+	errs := res.applyAllowListSet(ctx, serviceIDs, nil, entries, time.Minute)
+	r.NoError(errs["service-a"])
+	r.ErrorContains(errs["service-b"], "service is not ready")
+	r.NoError(errs["service-c"])
+
+	r.Equal([]provisioning.AllowListItem{
+		{IPAddress: "10.0.0.1/32", Comment: "portal"},
+		{IPAddress: "192.158.1.38/32", Comment: "vpn"},
+	}, allowLists["service-a"])
+	r.Equal([]provisioning.AllowListItem{{IPAddress: "192.158.1.38/32", Comment: "vpn"}}, allowLists["service-c"])
+
+	data := &AllowListSetResourceModel{}
+	diags := setAllowListSetResults(ctx, data, serviceIDs, errs)
+	r.Equal(0, diags.ErrorsCount())
+	r.Equal(1, diags.WarningsCount())
+	r.Equal(map[string]bool{"service-b": true}, failedAllowListSetServices(ctx, data.Results))
+
+	// Only a set that could not be applied anywhere fails
+	diags = setAllowListSetResults(ctx, data, []string{"service-b"}, errs)
+	r.Equal(1, diags.ErrorsCount())
+
+	// Removing the set only removes its entries
+	errs = res.applyAllowListSet(ctx, []string{"service-a"}, entries, nil, time.Minute)
+	r.NoError(errs["service-a"])
+	r.Equal([]provisioning.AllowListItem{{IPAddress: "10.0.0.1/32", Comment: "portal"}}, allowLists["service-a"])
+}
+
+func TestAllowListSetMissing(t *testing.T) {
+	r := require.New(t)
+
+	entries := []AllowListSetEntryModel{
+		{IPAddress: NewCIDRValue("10.0.0.1"), Comment: types.StringNull()},
+		{IPAddress: NewCIDRValue("10.0.0.2/32"), Comment: types.StringValue("vpn")},
+	}
+	r.NoError(allowListSetMissing([]provisioning.AllowListItem{
+		{IPAddress: "10.0.0.1/32"},
+		{IPAddress: "10.0.0.2/32", Comment: "vpn"},
+	}, entries))
+	r.EqualError(allowListSetMissing([]provisioning.AllowListItem{
+		{IPAddress: "10.0.0.2/32", Comment: "changed"},
+	}, entries), "entries missing or changed outside of Terraform: 10.0.0.1, 10.0.0.2/32")
+}
+
+func TestAllowListSetUpdateKeepsFailedRemovals(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	// The service removed from the set rejects the write
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if req.Method == http.MethodPut {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(skysql.ErrorResponse{
+				Errors: []skysql.ErrorDetails{{Message: "service is not ready"}},
+			})
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(provisioning.ReadAllowListResponse{{AllowList: []provisioning.AllowListItem{
+			{IPAddress: "192.158.1.38/32", Comment: "vpn"},
+		}}})
+	}))
+	defer srv.Close()
+
+	res := &AllowListSetResource{client: skysql.New(srv.URL, "[api-key]", "")}
+	schemaResp := &resource.SchemaResponse{}
+	res.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	timeoutsType := schemaResp.Schema.Blocks["timeouts"].Type().(timeouts.Type)
+
+	newState := func(serviceIDs ...string) tfsdk.State {
+		elements := make([]attr.Value, 0, len(serviceIDs))
+		for _, serviceID := range serviceIDs {
+			elements = append(elements, types.StringValue(serviceID))
+		}
+		data := &AllowListSetResourceModel{ID: types.StringValue("set")}
+		data.ServiceIDs = types.SetValueMust(types.StringType, elements)
+		data.Entries = []AllowListSetEntryModel{{IPAddress: NewCIDRValue("192.158.1.38/32"), Comment: types.StringValue("vpn")}}
+		data.Timeouts = timeouts.Value{Object: types.ObjectNull(timeoutsType.AttrTypes)}
+		r.False(setAllowListSetResults(ctx, data, serviceIDs, map[string]error{}).HasError())
+		state := tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}
+		r.False(state.Set(ctx, data).HasError())
+		return state
+	}
+
+	prior := newState("service-a", "service-b")
+	plan := newState("service-a")
+	resp := &resource.UpdateResponse{State: plan}
+	res.Update(ctx, resource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw},
+		State: prior,
+	}, resp)
+	r.True(resp.Diagnostics.HasError())
+
+	// The service stays in the set, so the removal is retried
+	var data AllowListSetResourceModel
+	r.False(resp.State.Get(ctx, &data).HasError())
+	serviceIDs, _ := allowListSetServiceIDs(ctx, data.ServiceIDs)
+	r.Equal([]string{"service-a", "service-b"}, serviceIDs)
+}
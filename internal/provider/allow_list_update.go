@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	sdkresource "github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql/provisioning"
+)
+
+// allowListLocks holds a mutex per service ID. The allow list API replaces
+// the whole list, so changes to the list of a service are serialized within
+// the provider.
+var allowListLocks sync.Map
+
+// lockAllowList locks the allow list of the service and returns the function
+// that unlocks it.
+func lockAllowList(serviceID string) func() {
+	lock, _ := allowListLocks.LoadOrStore(serviceID, &sync.Mutex{})
+	mu := lock.(*sync.Mutex)
+	mu.Lock()
+	return mu.Unlock
+}
+
+// Owners of the allow list of a service.
+const (
+	allowListOwnerService   = "skysql_service"
+	allowListOwnerAllowList = "skysql_allow_list"
+)
+
+// allowListOwners records, per service ID, the resource types that manage the
+// whole allow list of the service during a run.
+var (
+	allowListOwnersMu sync.Mutex
+	allowListOwners   = map[string]map[string]bool{}
+)
+
+// claimAllowList records owner as an owner of the allow list of the service
+// and returns the other owners seen so far in this run.
+func claimAllowList(serviceID string, owner string) []string {
+	allowListOwnersMu.Lock()
+	defer allowListOwnersMu.Unlock()
+
+	owners, ok := allowListOwners[serviceID]
+	if !ok {
+		owners = map[string]bool{}
+		allowListOwners[serviceID] = owners
+	}
+	owners[owner] = true
+
+	others := make([]string, 0, len(owners))
+	for o := range owners {
+		if o != owner {
+			others = append(others, o)
+		}
+	}
+	sort.Strings(others)
+	return others
+}
+
+// allowListOwnerConflict returns the diagnostic summary and detail for an
+// allow list that is managed by both skysql_service and skysql_allow_list.
+func allowListOwnerConflict(serviceID string) (string, string) {
+	return "Allow list managed twice",
+		fmt.Sprintf("The allow list of service %s is managed by both the allow_list attribute of skysql_service and a skysql_allow_list resource. "+
+			"Each apply overwrites the changes of the other. Remove allow_list from skysql_service and set ignore_allow_list = true, "+
+			"or remove the skysql_allow_list resource", serviceID)
+}
+
+// modifyAllowList reads the allow list of the service, applies change to it
+// and writes the result back. The list is locked for the duration of the
+// change, and the change is applied again to a fresh copy of the list when
+// the write conflicts with a concurrent modification. The write carries no
+// precondition, so a change made outside of the provider in between is only
+// detected when the API reports a conflict on its own.
+func modifyAllowList(ctx context.Context, client *skysql.Client, serviceID string, timeout time.Duration,
+	change func([]provisioning.AllowListItem) ([]provisioning.AllowListItem, error)) ([]provisioning.AllowListItem, error) {
+	unlock := lockAllowList(serviceID)
+	defer unlock()
+
+	var allowList []provisioning.AllowListItem
+	err := sdkresource.RetryContext(ctx, timeout, func() *sdkresource.RetryError {
+		current, err := readAllowList(ctx, client, serviceID)
+		if err != nil {
+			return sdkresource.NonRetryableError(err)
+		}
+
+		updated, err := change(current)
+		if err != nil {
+			return sdkresource.NonRetryableError(err)
+		}
+
+		allowList, err = client.UpdateServiceAllowListByID(ctx, serviceID, updated)
+		if errors.Is(err, skysql.ErrorConflict) {
+			tflog.Info(ctx, "Allow list was modified concurrently, retrying", map[string]interface{}{
+				"service_id": serviceID,
+			})
+			return sdkresource.RetryableError(err)
+		}
+		if err != nil {
+			return sdkresource.NonRetryableError(err)
+		}
+		return nil
+	})
+	return allowList, err
+}
+
+// readAllowList returns the allow list of the primary endpoint of the service.
+func readAllowList(ctx context.Context, client *skysql.Client, serviceID string) ([]provisioning.AllowListItem, error) {
+	allowListResp, err := client.ReadServiceAllowListByID(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	if len(allowListResp) == 0 {
+		return []provisioning.AllowListItem{}, nil
+	}
+	return allowListResp[0].AllowList, nil
+}
+
+// findAllowListItem returns the index of the entry for ip, -1 when the list
+// has none. A bare IP address matches its single-address CIDR.
+func findAllowListItem(allowList []provisioning.AllowListItem, ip string) int {
+	for i := range allowList {
+		if sameAllowListIP(allowList[i].IPAddress, ip) {
+			return i
+		}
+	}
+	return -1
+}
+
+// sameAllowListIP compares two allow list addresses by their canonical form,
+// e.g. a bare IP address and its single-address range are equal.
+func sameAllowListIP(a string, b string) bool {
+	canonicalA, err := canonicalCIDR(a)
+	if err != nil {
+		return a == b
+	}
+	canonicalB, err := canonicalCIDR(b)
+	if err != nil {
+		return false
+	}
+	return canonicalA == canonicalB
+}
@@ -0,0 +1,254 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	sdkresource "github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql/provisioning"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimAllowList(t *testing.T) {
+	r := require.New(t)
+
+	r.Empty(claimAllowList("claim-service-a", allowListOwnerService))
+	r.Empty(claimAllowList("claim-service-b", allowListOwnerAllowList))
+	// Planning the same resource again is not a conflict
+	r.Empty(claimAllowList("claim-service-a", allowListOwnerService))
+
+	r.Equal([]string{allowListOwnerService}, claimAllowList("claim-service-a", allowListOwnerAllowList))
+	r.Equal([]string{allowListOwnerAllowList}, claimAllowList("claim-service-a", allowListOwnerService))
+}
+
+func TestServiceSetAllowListStateIgnored(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	data := &ServiceResourceModel{
+		IgnoreAllowList:  types.BoolValue(true),
+		AllowList:        types.ListNull(allowListElementType),
+		ExpiredAllowList: types.ListNull(allowListElementType),
+	}
+	diags := (&ServiceResource{}).setAllowListState(ctx, data, []provisioning.AllowListItem{
+		{IPAddress: "192.158.1.38/32", Comment: "managed by skysql_allow_list"},
+	})
+	r.False(diags.HasError(), "%v", diags)
+	r.True(data.AllowList.IsNull())
+	r.True(data.ExpiredAllowList.IsNull())
+}
+
+// The test harness does not expose warnings, so the plans of both resources
+// are modified directly.
+func TestAllowListOwnerConflictWarning(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	const serviceID = "owner-conflict-service"
+
+	res := &ServiceAllowListResource{}
+	schemaResp := &resource.SchemaResponse{}
+	res.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	r.False(state.SetAttribute(ctx, path.Root("service_id"), types.StringValue(serviceID)).HasError())
+	r.False(state.SetAttribute(ctx, path.Root("allow_list"), []AllowListModel{
+		{IPAddress: NewCIDRValue("192.158.1.38/32"), Comment: types.StringValue("vpn"), ExpiresAt: types.StringNull()},
+	}).HasError())
+	plan := tfsdk.Plan{Schema: state.Schema, Raw: state.Raw}
+
+	allowListResp := &resource.ModifyPlanResponse{Plan: plan}
+	res.ModifyPlan(ctx, resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: state.Schema, Raw: state.Raw},
+		Plan:   plan,
+		State:  tfsdk.State{Schema: state.Schema, Raw: tftypes.NewValue(state.Schema.Type().TerraformType(ctx), nil)},
+	}, allowListResp)
+	r.False(allowListResp.Diagnostics.HasError(), "%v", allowListResp.Diagnostics)
+	r.Zero(allowListResp.Diagnostics.WarningsCount())
+
+	allowList, diags := allowListValue(ctx, []AllowListModel{
+		{IPAddress: NewCIDRValue("10.0.0.1/32"), Comment: types.StringNull(), ExpiresAt: types.StringNull()},
+	})
+	r.False(diags.HasError())
+	config := &ServiceResourceModel{AllowList: allowList}
+	servicePlan := &ServiceResourceModel{
+		ID:              types.StringValue(serviceID),
+		IgnoreAllowList: types.BoolValue(false),
+		AllowList:       allowList,
+	}
+	serviceResp := &resource.ModifyPlanResponse{}
+	modifyAllowListOwnerPlan(ctx, config, servicePlan, nil, serviceResp)
+	r.False(serviceResp.Diagnostics.HasError(), "%v", serviceResp.Diagnostics)
+	r.Equal(1, serviceResp.Diagnostics.WarningsCount())
+	r.Equal("Allow list managed twice", serviceResp.Diagnostics.Warnings()[0].Summary())
+}
+
+func TestServiceResourceIgnoreAllowList(t *testing.T) {
+	const serviceID = "dbdgf42002431"
+
+	testURL, expectRequest, closeAPI := mockSkySQLAPI(t)
+	defer closeAPI()
+	os.Setenv("TF_SKYSQL_API_KEY", "[api-key]")
+	os.Setenv("TF_SKYSQL_API_BASE_URL", testURL)
+
+	r := require.New(t)
+
+	configureOnce.Reset()
+	// The allow list is managed by a skysql_allow_list resource
+	service := &provisioning.Service{
+		ID:           serviceID,
+		Name:         "test-gcp",
+		Region:       "us-central1",
+		Provider:     "gcp",
+		Tier:         "foundation",
+		Topology:     "es-single",
+		Version:      "10.6.11-6-1",
+		Architecture: "amd64",
+		Size:         "sky-2x8",
+		Nodes:        1,
+		SSLEnabled:   true,
+		Status:       "ready",
+		IsActive:     true,
+		ServiceType:  "transactional",
+		Endpoints: []provisioning.Endpoint{
+			{
+				Name: "primary",
+				Ports: []provisioning.Port{
+					{
+						Name:    "readwrite",
+						Port:    3306,
+						Purpose: "readwrite",
+					},
+				},
+				AllowList: []provisioning.AllowListItem{
+					{
+						IPAddress: "192.158.1.38/32",
+						Comment:   "managed by skysql_allow_list",
+					},
+				},
+			},
+		},
+	}
+	service.StorageVolume.Size = 100
+	service.StorageVolume.VolumeType = "pd-ssd"
+	getService := func(w http.ResponseWriter, req *http.Request) {
+		r.Equal(
+			fmt.Sprintf("%s %s/%s", http.MethodGet, "/provisioning/v1/services", serviceID),
+			fmt.Sprintf("%s %s", req.Method, req.URL.Path))
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(service)
+	}
+
+	expectRequest(versionsResponse(t))
+	// Create service
+	expectRequest(func(w http.ResponseWriter, req *http.Request) {
+		r.Equal(http.MethodPost, req.Method)
+		r.Equal("/provisioning/v1/services", req.URL.Path)
+		payload := provisioning.CreateServiceRequest{}
+		r.NoError(json.NewDecoder(req.Body).Decode(&payload))
+		r.Empty(payload.AllowList)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(service)
+	})
+	// Wait for the service, read it back and refresh it
+	for i := 0; i < 3; i++ {
+		expectRequest(getService)
+	}
+	// The conflicting configuration fails the plan after the refresh
+	expectRequest(getService)
+	// Refresh, read the allow list back in the update and refresh again
+	for i := 0; i < 3; i++ {
+		expectRequest(getService)
+	}
+	expectRequest(func(w http.ResponseWriter, req *http.Request) {
+		r.Equal(
+			fmt.Sprintf("%s %s/%s", http.MethodDelete, "/provisioning/v1/services", serviceID),
+			fmt.Sprintf("%s %s", req.Method, req.URL.Path))
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(service)
+	})
+	expectRequest(func(w http.ResponseWriter, req *http.Request) {
+		r.Equal(
+			fmt.Sprintf("%s %s/%s", http.MethodGet, "/provisioning/v1/services", serviceID),
+			fmt.Sprintf("%s %s", req.Method, req.URL.Path))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(&skysql.ErrorResponse{
+			Code: http.StatusNotFound,
+		})
+	})
+
+	serviceConfig := func(allowList string) string {
+		return fmt.Sprintf(`
+resource "skysql_service" default {
+  service_type   = "transactional"
+  topology       = "es-single"
+  cloud_provider = "gcp"
+  region         = "us-central1"
+  name           = "test-gcp"
+  architecture   = "amd64"
+  nodes          = 1
+  size           = "sky-2x8"
+  storage        = 100
+  ssl_enabled    = true
+  version        = "10.6.11-6-1"
+  wait_for_creation = true
+  wait_for_deletion = true
+  wait_for_update   = true
+  deletion_protection = false
+  %s
+}
+`, allowList)
+	}
+
+	sdkresource.Test(t, sdkresource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"skysql": providerserver.NewProtocol6WithError(New("")()),
+		},
+		Steps: []sdkresource.TestStep{
+			{
+				Config: serviceConfig(`ignore_allow_list = true`),
+				Check: sdkresource.ComposeAggregateTestCheckFunc(
+					sdkresource.TestCheckResourceAttr("skysql_service.default", "id", serviceID),
+					sdkresource.TestCheckResourceAttr("skysql_service.default", "ignore_allow_list", "true"),
+					sdkresource.TestCheckNoResourceAttr("skysql_service.default", "allow_list.0.ip"),
+				),
+			},
+			{
+				Config: serviceConfig(`ignore_allow_list = true
+  allow_list = [
+    {
+      "ip": "10.0.0.1/32",
+      "comment": "office"
+    }
+  ]`),
+				ExpectError: regexp.MustCompile("allow_list can not be set when ignore_allow_list is true"),
+			},
+			{
+				Config: serviceConfig(`ignore_allow_list = false`),
+				Check: sdkresource.ComposeAggregateTestCheckFunc(
+					sdkresource.TestCheckResourceAttr("skysql_service.default", "ignore_allow_list", "false"),
+					sdkresource.TestCheckResourceAttr("skysql_service.default", "allow_list.#", "1"),
+					sdkresource.TestCheckResourceAttr("skysql_service.default", "allow_list.0.ip", "192.158.1.38/32"),
+					sdkresource.TestCheckResourceAttr("skysql_service.default", "allow_list.0.comment", "managed by skysql_allow_list"),
+				),
+			},
+		},
+	})
+}
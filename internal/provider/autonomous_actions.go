@@ -0,0 +1,250 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql/autonomous"
+)
+
+// AutonomousActionModel is a generic autonomous action, for groups the typed
+// blocks do not cover yet.
+type AutonomousActionModel struct {
+	ID      types.String `tfsdk:"id"`
+	Group   types.String `tfsdk:"group"`
+	Enabled types.Bool   `tfsdk:"enabled"`
+	Params  JSONValue    `tfsdk:"params"`
+}
+
+var autonomousActionElementType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"id":      types.StringType,
+		"group":   types.StringType,
+		"enabled": types.BoolType,
+		"params":  JSONType{},
+	},
+}
+
+var autonomousActionBlock = schema.ListNestedBlock{
+	Description: "A generic autonomous action. Actions are matched by group, each group can only be declared once, " +
+		"either as an `action` block or as one of the typed attributes.",
+	NestedObject: schema.NestedBlockObject{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the action.",
+			},
+			"group": schema.StringAttribute{
+				Required:    true,
+				Description: "The group of the action, e.g. autoScaleDisk",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the action is enabled. Defaults to true",
+				PlanModifiers: []planmodifier.Bool{
+					boolDefault(true),
+				},
+			},
+			"params": schema.StringAttribute{
+				Required:    true,
+				CustomType:  JSONType{},
+				Description: "The parameters of the action as a JSON-encoded string, e.g. jsonencode({ max_storage_size_gbs = 200 })",
+				Validators: []validator.String{
+					jsonValidator{},
+				},
+			},
+		},
+	},
+}
+
+// autonomousTypedGroups are the action groups that have a typed attribute.
+var autonomousTypedGroups = map[string]string{
+	autonomous.AutoScaleDiskActionGroup:            "auto_scale_disk",
+	autonomous.AutoScaleNodesHorizontalActionGroup: "auto_scale_nodes_horizontal",
+	autonomous.AutoScaleNodesVerticalActionGroup:   "auto_scale_nodes_vertical",
+}
+
+func autonomousActions(ctx context.Context, data *AutonomousResourceModel) ([]AutonomousActionModel, diag.Diagnostics) {
+	actions := make([]AutonomousActionModel, 0, len(data.Actions.Elements()))
+	if data.Actions.IsNull() || data.Actions.IsUnknown() {
+		return actions, nil
+	}
+	diags := data.Actions.ElementsAs(ctx, &actions, false)
+	return actions, diags
+}
+
+func setAutonomousActions(ctx context.Context, data *AutonomousResourceModel, actions []AutonomousActionModel) diag.Diagnostics {
+	list, diags := types.ListValueFrom(ctx, autonomousActionElementType, actions)
+	data.Actions = list
+	return diags
+}
+
+// autonomousActionRequests converts the action blocks to their API
+// representation.
+func autonomousActionRequests(actions []AutonomousActionModel) []autonomous.AutoScaleAction {
+	requests := make([]autonomous.AutoScaleAction, 0, len(actions))
+	for _, action := range actions {
+		requests = append(requests, autonomous.AutoScaleAction{
+			Group:   action.Group.ValueString(),
+			Enabled: action.Enabled.ValueBool(),
+			Params:  json.RawMessage(action.Params.ValueString()),
+		})
+	}
+	return requests
+}
+
+// autonomousActionFromResponse reads an action back into an action block.
+// Params are kept as the API returns them, semantic equality hides the
+// difference in formatting.
+func autonomousActionFromResponse(action autonomous.ActionResponse) AutonomousActionModel {
+	params := string(action.Params)
+	if params == "" || params == "null" {
+		params = "{}"
+	}
+	return AutonomousActionModel{
+		ID:      types.StringValue(action.ID),
+		Group:   types.StringValue(action.Group),
+		Enabled: types.BoolValue(action.Enabled),
+		Params:  NewJSONValue(params),
+	}
+}
+
+// mergeAutonomousActions reads the actions of the service back into the
+// action blocks. Declared actions keep their position, actions that are gone
+// are dropped and actions of groups without a typed attribute that are not
+// declared are appended, so they show up as a difference.
+func mergeAutonomousActions(declared []AutonomousActionModel, remote []autonomous.ActionResponse) []AutonomousActionModel {
+	byGroup := make(map[string]autonomous.ActionResponse, len(remote))
+	for _, action := range remote {
+		byGroup[action.Group] = action
+	}
+
+	merged := make([]AutonomousActionModel, 0, len(remote))
+	seen := make(map[string]bool, len(declared))
+	for _, action := range declared {
+		group := action.Group.ValueString()
+		seen[group] = true
+		if response, ok := byGroup[group]; ok {
+			merged = append(merged, autonomousActionFromResponse(response))
+		}
+	}
+	for _, action := range remote {
+		if _, typed := autonomousTypedGroups[action.Group]; typed || seen[action.Group] {
+			continue
+		}
+		seen[action.Group] = true
+		merged = append(merged, autonomousActionFromResponse(action))
+	}
+	return merged
+}
+
+// declaredAutonomousGroups returns the groups of the action blocks.
+func declaredAutonomousGroups(actions []AutonomousActionModel) map[string]bool {
+	groups := make(map[string]bool, len(actions))
+	for _, action := range actions {
+		groups[action.Group.ValueString()] = true
+	}
+	return groups
+}
+
+// removedAutonomousActions returns the actions of state whose group is no
+// longer declared in plan.
+func removedAutonomousActions(state []AutonomousActionModel, plan []AutonomousActionModel) []AutonomousActionModel {
+	groups := declaredAutonomousGroups(plan)
+	removed := make([]AutonomousActionModel, 0)
+	for _, action := range state {
+		if !groups[action.Group.ValueString()] {
+			removed = append(removed, action)
+		}
+	}
+	return removed
+}
+
+// modifyAutonomousActionsPlan rejects groups declared twice and keeps the IDs
+// of actions that are already known for the same group.
+func modifyAutonomousActionsPlan(ctx context.Context, plan *AutonomousResourceModel, state *AutonomousResourceModel, resp *resource.ModifyPlanResponse) {
+	if plan.Actions.IsUnknown() {
+		return
+	}
+	actions, diags := autonomousActions(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	var prior []AutonomousActionModel
+	if state != nil {
+		prior, diags = autonomousActions(ctx, state)
+		resp.Diagnostics.Append(diags...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	typed := map[string]types.Object{
+		autonomous.AutoScaleDiskActionGroup:            plan.AutoScaleDiskAction,
+		autonomous.AutoScaleNodesHorizontalActionGroup: plan.AutoScaleNodesHorizontalAction,
+		autonomous.AutoScaleNodesVerticalActionGroup:   plan.AutoScaleNodesVerticalAction,
+	}
+	seen := make(map[string]bool, len(actions))
+	for i, action := range actions {
+		if action.Group.IsUnknown() {
+			continue
+		}
+		group := action.Group.ValueString()
+		if seen[group] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("action").AtListIndex(i).AtName("group"),
+				"Duplicate autonomous action",
+				fmt.Sprintf("The group %q is declared more than once.", group),
+			)
+			continue
+		}
+		seen[group] = true
+		if object, ok := typed[group]; ok && !object.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("action").AtListIndex(i).AtName("group"),
+				"Duplicate autonomous action",
+				fmt.Sprintf("The group %q is already declared by %s.", group, autonomousTypedGroups[group]),
+			)
+			continue
+		}
+
+		actions[i].ID = types.StringUnknown()
+		for _, p := range prior {
+			if p.Group.ValueString() == group && !p.ID.IsNull() && !p.ID.IsUnknown() {
+				actions[i].ID = p.ID
+			}
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(setAutonomousActions(ctx, plan, actions)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("action"), plan.Actions)...)
+}
+
+// deleteAutonomousActions deletes the actions that were created.
+func (r *AutonomousResource) deleteAutonomousActions(ctx context.Context, actions []AutonomousActionModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, action := range actions {
+		if action.ID.IsNull() || action.ID.IsUnknown() {
+			continue
+		}
+		if err := r.client.DeleteAutonomousAction(ctx, action.ID.ValueString()); err != nil {
+			diags.AddError("error deleting skysql_autonomous resource", err.Error())
+		}
+	}
+	return diags
+}
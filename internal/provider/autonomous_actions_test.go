@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql/autonomous"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql/provisioning"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONValueSemanticEquals(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	equal, diags := NewJSONValue(`{"min_nodes":1,"max_nodes":3}`).StringSemanticEquals(ctx, NewJSONValue("{\n  \"max_nodes\": 3,\n  \"min_nodes\": 1\n}"))
+	r.False(diags.HasError())
+	r.True(equal)
+
+	equal, _ = NewJSONValue(`{"max_nodes":3}`).StringSemanticEquals(ctx, NewJSONValue(`{"max_nodes":4}`))
+	r.False(equal)
+
+	equal, _ = NewJSONValue(`{"max_nodes":`).StringSemanticEquals(ctx, NewJSONValue(`{"max_nodes":3}`))
+	r.False(equal)
+}
+
+func TestMergeAutonomousActions(t *testing.T) {
+	r := require.New(t)
+
+	declared := []AutonomousActionModel{
+		{ID: types.StringUnknown(), Group: types.StringValue("scheduledScaling"), Enabled: types.BoolValue(true), Params: NewJSONValue(`{"cron": "0 8 * * *"}`)},
+		{ID: types.StringValue("gone"), Group: types.StringValue("autoScaleReplicas"), Enabled: types.BoolValue(true), Params: NewJSONValue(`{}`)},
+		{ID: types.StringUnknown(), Group: types.StringValue(autonomous.AutoScaleDiskActionGroup), Enabled: types.BoolValue(false), Params: NewJSONValue(`{}`)},
+	}
+	remote := []autonomous.ActionResponse{
+		{ID: "disk", Group: autonomous.AutoScaleDiskActionGroup, Params: json.RawMessage(`{"max_storage_size_gbs":200}`)},
+		{ID: "vertical", Group: autonomous.AutoScaleNodesVerticalActionGroup, Enabled: true, Params: json.RawMessage(`{}`)},
+		{ID: "scheduled", Group: "scheduledScaling", Enabled: true, Params: json.RawMessage(`{"cron":"0 8 * * *"}`)},
+		{ID: "other", Group: "otherAction", Enabled: true},
+	}
+
+	// Declared actions keep their position, typed groups that are not declared
+	// are left to the typed attributes
+	r.Equal([]AutonomousActionModel{
+		{ID: types.StringValue("scheduled"), Group: types.StringValue("scheduledScaling"), Enabled: types.BoolValue(true), Params: NewJSONValue(`{"cron":"0 8 * * *"}`)},
+		{ID: types.StringValue("disk"), Group: types.StringValue(autonomous.AutoScaleDiskActionGroup), Enabled: types.BoolValue(false), Params: NewJSONValue(`{"max_storage_size_gbs":200}`)},
+		{ID: types.StringValue("other"), Group: types.StringValue("otherAction"), Enabled: types.BoolValue(true), Params: NewJSONValue(`{}`)},
+	}, mergeAutonomousActions(declared, remote))
+
+	r.Equal(declared[1:], removedAutonomousActions(declared, declared[:1]))
+}
+
+func TestActionsResponseToDataEnabled(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	data := &AutonomousResourceModel{Actions: types.ListNull(autonomousActionElementType)}
+	diags := (&AutonomousResource{}).actionsResponseToData(ctx, []autonomous.ActionResponse{
+		{ID: "disk", Group: autonomous.AutoScaleDiskActionGroup, Enabled: false, Params: json.RawMessage(`{"max_storage_size_gbs":200}`)},
+		{ID: "horizontal", Group: autonomous.AutoScaleNodesHorizontalActionGroup, Enabled: true, Params: json.RawMessage(`{"min_nodes":1,"max_nodes":3}`)},
+	}, data)
+	r.False(diags.HasError(), "%v", diags)
+
+	var disk AutoScaleDiskAction
+	r.False(data.AutoScaleDiskAction.As(ctx, &disk, basetypes.ObjectAsOptions{}).HasError())
+	r.Equal(types.BoolValue(false), disk.Enabled)
+	r.Equal(types.Int64Value(200), disk.MaxStorageSizeGBs)
+
+	var horizontal AutoScaleNodesHorizontalAction
+	r.False(data.AutoScaleNodesHorizontalAction.As(ctx, &horizontal, basetypes.ObjectAsOptions{}).HasError())
+	r.Equal(types.BoolValue(true), horizontal.Enabled)
+
+	r.False(autonomous.NewAutoScaleDiskAction(200, false).Enabled)
+}
+
+func TestAutonomousServiceName(t *testing.T) {
+	r := require.New(t)
+
+	service := &provisioning.Service{ID: "dbdgf42002419", Name: "orders-renamed"}
+	registered := []autonomous.ActionResponse{
+		{Group: autonomous.AutoScaleDiskActionGroup, ServiceName: "orders"},
+	}
+
+	// The name the actions are registered under differs from the service,
+	// so the rename shows up in the plan
+	r.Equal(types.StringValue("orders"), autonomousServiceName(registered, types.StringValue("orders"), service))
+	// Without actions the prior name is kept
+	r.Equal(types.StringValue("orders"), autonomousServiceName(nil, types.StringValue("orders"), service))
+	// After an import the name of the service is used
+	r.Equal(types.StringValue("orders-renamed"), autonomousServiceName(nil, types.StringNull(), service))
+}
+
+func TestAutonomousResourceReadWithoutActions(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	// Every action was deleted outside of Terraform
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		switch req.URL.Path {
+		case "/provisioning/v1/services/service-a":
+			json.NewEncoder(w).Encode(provisioning.Service{ID: "service-a", Name: "service-a"})
+		default:
+			json.NewEncoder(w).Encode([]autonomous.ActionResponse{})
+		}
+	}))
+	defer srv.Close()
+
+	res := &AutonomousResource{client: skysql.New(srv.URL, "[api-key]", "")}
+	schemaResp := &resource.SchemaResponse{}
+	res.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	r.False(state.Set(ctx, &AutonomousResourceModel{
+		ID:          types.StringValue("service-a"),
+		ServiceID:   types.StringValue("service-a"),
+		ServiceName: types.StringValue("service-a"),
+		AutoScaleDiskAction: types.ObjectValueMust(autoScaleDiskAttrTypes, map[string]attr.Value{
+			"id":                   types.StringValue("disk"),
+			"enabled":              types.BoolValue(true),
+			"max_storage_size_gbs": types.Int64Value(200),
+		}),
+		AutoScaleNodesHorizontalAction: types.ObjectNull(autoScaleNodesHorizontalAttrTypes),
+		AutoScaleNodesVerticalAction:   types.ObjectNull(autoScaleNodesVerticalAttrTypes),
+		Actions: types.ListValueMust(autonomousActionElementType, []attr.Value{
+			types.ObjectValueMust(autonomousActionElementType.AttrTypes, map[string]attr.Value{
+				"id":      types.StringValue("scheduled"),
+				"group":   types.StringValue("scheduledScaling"),
+				"enabled": types.BoolValue(true),
+				"params":  NewJSONValue(`{}`),
+			}),
+		}),
+	}).HasError())
+
+	resp := &resource.ReadResponse{State: state}
+	res.Read(ctx, resource.ReadRequest{State: state}, resp)
+	r.False(resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	var data AutonomousResourceModel
+	r.False(resp.State.Get(ctx, &data).HasError())
+	r.True(data.AutoScaleDiskAction.IsNull())
+	r.Empty(data.Actions.Elements())
+	r.Equal(types.StringValue("service-a"), data.ServiceName)
+}
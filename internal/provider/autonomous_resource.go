@@ -18,6 +18,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql"
 	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql/autonomous"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql/provisioning"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
@@ -43,6 +44,7 @@ type AutonomousResourceModel struct {
 	AutoScaleDiskAction            types.Object `tfsdk:"auto_scale_disk"`
 	AutoScaleNodesHorizontalAction types.Object `tfsdk:"auto_scale_nodes_horizontal"`
 	AutoScaleNodesVerticalAction   types.Object `tfsdk:"auto_scale_nodes_vertical"`
+	Actions                        types.List   `tfsdk:"action"`
 }
 
 func (r *AutonomousResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -72,8 +74,10 @@ func (r *AutonomousResource) Schema(ctx context.Context, req resource.SchemaRequ
 				},
 			},
 			"service_name": schema.StringAttribute{
-				Required:    true,
-				Description: "The name of the service to manage the autonomous features for.",
+				Optional: true,
+				Computed: true,
+				Description: "The name of the service to manage the autonomous features for. " +
+					"Defaults to the current name of the service, so a renamed service is updated in the autonomous actions.",
 			},
 			"auto_scale_disk": schema.SingleNestedAttribute{
 				Required: false,
@@ -92,6 +96,14 @@ func (r *AutonomousResource) Schema(ctx context.Context, req resource.SchemaRequ
 							stringplanmodifier.RequiresReplace(),
 						},
 					},
+					"enabled": schema.BoolAttribute{
+						Optional:    true,
+						Computed:    true,
+						Description: "Whether the action is enabled. Defaults to true",
+						PlanModifiers: []planmodifier.Bool{
+							boolDefault(true),
+						},
+					},
 					"max_storage_size_gbs": schema.Int64Attribute{
 						Required: false,
 						Optional: true,
@@ -112,6 +124,14 @@ func (r *AutonomousResource) Schema(ctx context.Context, req resource.SchemaRequ
 						Optional: false,
 						Computed: true,
 					},
+					"enabled": schema.BoolAttribute{
+						Optional:    true,
+						Computed:    true,
+						Description: "Whether the action is enabled. Defaults to true",
+						PlanModifiers: []planmodifier.Bool{
+							boolDefault(true),
+						},
+					},
 					"max_node_size": schema.StringAttribute{
 						Required: false,
 						Optional: true,
@@ -137,6 +157,14 @@ func (r *AutonomousResource) Schema(ctx context.Context, req resource.SchemaRequ
 						Optional: false,
 						Computed: true,
 					},
+					"enabled": schema.BoolAttribute{
+						Optional:    true,
+						Computed:    true,
+						Description: "Whether the action is enabled. Defaults to true",
+						PlanModifiers: []planmodifier.Bool{
+							boolDefault(true),
+						},
+					},
 					"min_nodes": schema.Int64Attribute{
 						Required: false,
 						Optional: true,
@@ -150,39 +178,48 @@ func (r *AutonomousResource) Schema(ctx context.Context, req resource.SchemaRequ
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"action": autonomousActionBlock,
+		},
 	}
 }
 
 var autoScaleDiskAttrTypes = map[string]attr.Type{
 	"id":                   types.StringType,
+	"enabled":              types.BoolType,
 	"max_storage_size_gbs": types.Int64Type,
 }
 
 type AutoScaleDiskAction struct {
 	ID                types.String `tfsdk:"id"`
+	Enabled           types.Bool   `tfsdk:"enabled"`
 	MaxStorageSizeGBs types.Int64  `tfsdk:"max_storage_size_gbs"`
 }
 
 var autoScaleNodesVerticalAttrTypes = map[string]attr.Type{
 	"id":            types.StringType,
+	"enabled":       types.BoolType,
 	"max_node_size": types.StringType,
 	"min_node_size": types.StringType,
 }
 
 type AutoScaleNodesVerticalAction struct {
 	ID          types.String `tfsdk:"id"`
+	Enabled     types.Bool   `tfsdk:"enabled"`
 	MaxNodeSize types.String `tfsdk:"max_node_size"`
 	MinNodeSize types.String `tfsdk:"min_node_size"`
 }
 
 var autoScaleNodesHorizontalAttrTypes = map[string]attr.Type{
 	"id":        types.StringType,
+	"enabled":   types.BoolType,
 	"min_nodes": types.Int64Type,
 	"max_nodes": types.Int64Type,
 }
 
 type AutoScaleNodesHorizontalAction struct {
 	ID       types.String `tfsdk:"id"`
+	Enabled  types.Bool   `tfsdk:"enabled"`
 	MinNodes types.Int64  `tfsdk:"min_nodes"`
 	MaxNodes types.Int64  `tfsdk:"max_nodes"`
 }
@@ -225,6 +262,10 @@ func (r *AutonomousResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
+	if data.ServiceName.IsNull() || data.ServiceName.IsUnknown() {
+		data.ServiceName = types.StringValue(service.Name)
+	}
+
 	request := autonomous.SetAutonomousActionsRequest{
 		ServiceID:   data.ID.ValueString(),
 		ServiceName: data.ServiceName.ValueString(),
@@ -242,6 +283,7 @@ func (r *AutonomousResource) Create(ctx context.Context, req resource.CreateRequ
 			request.Actions,
 			autonomous.NewAutoScaleDiskAction(
 				action.MaxStorageSizeGBs.ValueInt64(),
+				action.Enabled.ValueBool(),
 			),
 		)
 	} else {
@@ -259,6 +301,7 @@ func (r *AutonomousResource) Create(ctx context.Context, req resource.CreateRequ
 			autonomous.NewAutoScaleNodesVerticalAction(
 				action.MaxNodeSize.ValueString(),
 				action.MinNodeSize.ValueString(),
+				action.Enabled.ValueBool(),
 			),
 		)
 	} else {
@@ -267,7 +310,7 @@ func (r *AutonomousResource) Create(ctx context.Context, req resource.CreateRequ
 
 	if !data.AutoScaleNodesHorizontalAction.IsUnknown() && !data.AutoScaleNodesHorizontalAction.IsNull() {
 
-		if Contains([]string{"es-single", "standalone"}, service.Topology) {
+		if Contains(horizontalScalingUnsupportedTopologies, service.Topology) {
 			resp.Diagnostics.AddError("Can not set horizontal scaling for service with topology", service.Topology)
 			return
 		}
@@ -282,19 +325,27 @@ func (r *AutonomousResource) Create(ctx context.Context, req resource.CreateRequ
 			autonomous.NewAutoScaleNodesHorizontalAction(
 				action.MinNodes.ValueInt64(),
 				action.MaxNodes.ValueInt64(),
+				action.Enabled.ValueBool(),
 			),
 		)
 	} else {
 		data.AutoScaleNodesHorizontalAction = types.ObjectNull(autoScaleNodesHorizontalAttrTypes)
 	}
 
+	declared, diags := autonomousActions(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	request.Actions = append(request.Actions, autonomousActionRequests(declared)...)
+
 	if len(request.Actions) > 0 {
 		actions, err := r.client.SetAutonomousActions(ctx, request)
 		if err != nil {
 			resp.Diagnostics.AddError("error creating skysql_autonomous resource", err.Error())
 			return
 		}
-		resp.Diagnostics.Append(r.actionsResponseToData(actions, data)...)
+		resp.Diagnostics.Append(r.actionsResponseToData(ctx, actions, data)...)
 		if resp.Diagnostics.HasError() {
 			return
 		}
@@ -314,7 +365,7 @@ func (r *AutonomousResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	_, err := r.client.GetServiceByID(ctx, data.ServiceID.ValueString())
+	service, err := r.client.GetServiceByID(ctx, data.ServiceID.ValueString())
 	if err != nil {
 		if errors.Is(err, skysql.ErrorServiceNotFound) {
 			tflog.Warn(ctx, "SkySQL service not found, removing from state", map[string]interface{}{
@@ -334,14 +385,14 @@ func (r *AutonomousResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	if len(actions) == 0 {
-		data.AutoScaleDiskAction = types.ObjectNull(autoScaleDiskAttrTypes)
-		data.AutoScaleNodesVerticalAction = types.ObjectNull(autoScaleNodesVerticalAttrTypes)
-		data.AutoScaleNodesHorizontalAction = types.ObjectNull(autoScaleNodesHorizontalAttrTypes)
-		return
-	}
+	data.ServiceName = autonomousServiceName(actions, data.ServiceName, service)
+
+	// Actions deleted outside of Terraform are no longer in the response
+	data.AutoScaleDiskAction = types.ObjectNull(autoScaleDiskAttrTypes)
+	data.AutoScaleNodesVerticalAction = types.ObjectNull(autoScaleNodesVerticalAttrTypes)
+	data.AutoScaleNodesHorizontalAction = types.ObjectNull(autoScaleNodesHorizontalAttrTypes)
 
-	resp.Diagnostics.Append(r.actionsResponseToData(actions, data)...)
+	resp.Diagnostics.Append(r.actionsResponseToData(ctx, actions, data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -352,9 +403,17 @@ func (r *AutonomousResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 }
 
-func (r *AutonomousResource) actionsResponseToData(actions []autonomous.ActionResponse, data *AutonomousResourceModel) diag.Diagnostics {
-	var diags diag.Diagnostics
+func (r *AutonomousResource) actionsResponseToData(ctx context.Context, actions []autonomous.ActionResponse, data *AutonomousResourceModel) diag.Diagnostics {
+	declared, diags := autonomousActions(ctx, data)
+	if diags.HasError() {
+		return diags
+	}
+	groups := declaredAutonomousGroups(declared)
 	for _, action := range actions {
+		// Groups declared as action blocks are read back into the blocks
+		if groups[action.Group] {
+			continue
+		}
 		switch action.Group {
 		case autonomous.AutoScaleDiskActionGroup:
 			params := autonomous.AutoScaleDiskActionParams{}
@@ -366,6 +425,7 @@ func (r *AutonomousResource) actionsResponseToData(actions []autonomous.ActionRe
 			}
 			data.AutoScaleDiskAction = types.ObjectValueMust(autoScaleDiskAttrTypes, map[string]attr.Value{
 				"id":                   types.StringValue(action.ID),
+				"enabled":              types.BoolValue(action.Enabled),
 				"max_storage_size_gbs": types.Int64Value(params.MaxStorageSizeGBs),
 			})
 		case autonomous.AutoScaleNodesVerticalActionGroup:
@@ -377,6 +437,7 @@ func (r *AutonomousResource) actionsResponseToData(actions []autonomous.ActionRe
 			}
 			data.AutoScaleNodesVerticalAction = types.ObjectValueMust(autoScaleNodesVerticalAttrTypes, map[string]attr.Value{
 				"id":            types.StringValue(action.ID),
+				"enabled":       types.BoolValue(action.Enabled),
 				"max_node_size": types.StringValue(params.MaxNodeSize),
 				"min_node_size": types.StringValue(params.MinNodeSize),
 			})
@@ -389,11 +450,13 @@ func (r *AutonomousResource) actionsResponseToData(actions []autonomous.ActionRe
 			}
 			data.AutoScaleNodesHorizontalAction = types.ObjectValueMust(autoScaleNodesHorizontalAttrTypes, map[string]attr.Value{
 				"id":        types.StringValue(action.ID),
+				"enabled":   types.BoolValue(action.Enabled),
 				"min_nodes": types.Int64Value(params.MinNodes),
 				"max_nodes": types.Int64Value(params.MaxNodes),
 			})
 		}
 	}
+	diags.Append(setAutonomousActions(ctx, data, mergeAutonomousActions(declared, actions))...)
 	return diags
 }
 
@@ -427,9 +490,14 @@ func (r *AutonomousResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
+	state.ServiceName = plan.ServiceName
+	if state.ServiceName.IsNull() || state.ServiceName.IsUnknown() {
+		state.ServiceName = types.StringValue(service.Name)
+	}
+
 	request := autonomous.SetAutonomousActionsRequest{
 		ServiceID:   plan.ID.ValueString(),
-		ServiceName: plan.ServiceName.ValueString(),
+		ServiceName: state.ServiceName.ValueString(),
 		Actions:     make([]autonomous.AutoScaleAction, 0, 3),
 	}
 
@@ -445,6 +513,15 @@ func (r *AutonomousResource) Update(ctx context.Context, req resource.UpdateRequ
 		r.deleteAutoScaleDiskAction(ctx, state)
 	}
 
+	declared, diags := autonomousActions(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	prior, diags := autonomousActions(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.deleteAutonomousActions(ctx, removedAutonomousActions(prior, declared))...)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -459,6 +536,7 @@ func (r *AutonomousResource) Update(ctx context.Context, req resource.UpdateRequ
 			request.Actions,
 			autonomous.NewAutoScaleDiskAction(
 				action.MaxStorageSizeGBs.ValueInt64(),
+				action.Enabled.ValueBool(),
 			),
 		)
 	} else {
@@ -476,6 +554,7 @@ func (r *AutonomousResource) Update(ctx context.Context, req resource.UpdateRequ
 			autonomous.NewAutoScaleNodesVerticalAction(
 				action.MaxNodeSize.ValueString(),
 				action.MinNodeSize.ValueString(),
+				action.Enabled.ValueBool(),
 			),
 		)
 		var diags diag.Diagnostics
@@ -488,7 +567,7 @@ func (r *AutonomousResource) Update(ctx context.Context, req resource.UpdateRequ
 	}
 
 	if !plan.AutoScaleNodesHorizontalAction.IsNull() {
-		if Contains([]string{"es-single", "standalone"}, service.Topology) {
+		if Contains(horizontalScalingUnsupportedTopologies, service.Topology) {
 			resp.Diagnostics.AddError("Can not set horizontal scaling for service with topology", service.Topology)
 			return
 		}
@@ -502,19 +581,23 @@ func (r *AutonomousResource) Update(ctx context.Context, req resource.UpdateRequ
 			autonomous.NewAutoScaleNodesHorizontalAction(
 				action.MinNodes.ValueInt64(),
 				action.MaxNodes.ValueInt64(),
+				action.Enabled.ValueBool(),
 			),
 		)
 	} else {
 		state.AutoScaleNodesHorizontalAction = types.ObjectNull(autoScaleNodesHorizontalAttrTypes)
 	}
 
+	request.Actions = append(request.Actions, autonomousActionRequests(declared)...)
+	state.Actions = plan.Actions
+
 	if len(request.Actions) > 0 {
 		actions, err := r.client.SetAutonomousActions(ctx, request)
 		if err != nil {
 			resp.Diagnostics.AddError("error creating skysql_autonomous resource", err.Error())
 			return
 		}
-		resp.Diagnostics.Append(r.actionsResponseToData(actions, state)...)
+		resp.Diagnostics.Append(r.actionsResponseToData(ctx, actions, state)...)
 		if resp.Diagnostics.HasError() {
 			return
 		}
@@ -555,6 +638,13 @@ func (r *AutonomousResource) Delete(ctx context.Context, req resource.DeleteRequ
 			return
 		}
 	}
+
+	actions, diags := autonomousActions(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.deleteAutonomousActions(ctx, actions)...)
 }
 
 func (r *AutonomousResource) deleteAutoScaleDiskAction(ctx context.Context, data *AutonomousResourceModel) diag.Diagnostics {
@@ -606,7 +696,13 @@ func (r *AutonomousResource) deleteAutoScaleNodesHorizontalAction(ctx context.Co
 }
 
 func (r *AutonomousResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	id, err := resolveServiceImportID(ctx, r.client, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Can not import resource", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("service_id"), id)...)
 }
 
 func (r *AutonomousResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
@@ -628,4 +724,85 @@ func (r *AutonomousResource) ModifyPlan(ctx context.Context, req resource.Modify
 			"Cant set both horizontal and vertical scaling for service")
 		return
 	}
+
+	var state *AutonomousResourceModel
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	modifyAutonomousActionsPlan(ctx, &plan, state, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	lookup := &autonomousServiceLookup{client: r.client, serviceID: plan.ServiceID}
+	r.modifyAutonomousServiceNamePlan(ctx, req, &plan, state, lookup, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.validateAutonomousPlan(ctx, &plan, state, lookup, resp)
+}
+
+// autonomousServiceLookup reads the service at most once while planning.
+type autonomousServiceLookup struct {
+	client    *skysql.Client
+	serviceID types.String
+	service   *provisioning.Service
+	err       error
+	done      bool
+}
+
+// get returns the service, nil when it is not known yet or was not found.
+func (l *autonomousServiceLookup) get(ctx context.Context) (*provisioning.Service, error) {
+	if l.client == nil || l.serviceID.IsUnknown() || l.serviceID.IsNull() {
+		return nil, nil
+	}
+	if !l.done {
+		l.done = true
+		l.service, l.err = l.client.GetServiceByID(ctx, l.serviceID.ValueString())
+		// A missing service is reported by the apply
+		if errors.Is(l.err, skysql.ErrorServiceNotFound) {
+			l.service, l.err = nil, nil
+		}
+	}
+	return l.service, l.err
+}
+
+// autonomousServiceName returns the service name the actions are registered
+// under, so a renamed service shows up as a difference. Without actions the
+// prior name is kept, or the name of the service after an import.
+func autonomousServiceName(actions []autonomous.ActionResponse, prior types.String, service *provisioning.Service) types.String {
+	for _, action := range actions {
+		if action.ServiceName != "" {
+			return types.StringValue(action.ServiceName)
+		}
+	}
+	if prior.IsNull() || prior.IsUnknown() || prior.ValueString() == "" {
+		return types.StringValue(service.Name)
+	}
+	return prior
+}
+
+// modifyAutonomousServiceNamePlan plans the current name of the service when
+// service_name is not configured, so renaming the service updates the
+// actions.
+func (r *AutonomousResource) modifyAutonomousServiceNamePlan(ctx context.Context, req resource.ModifyPlanRequest, plan *AutonomousResourceModel, state *AutonomousResourceModel, lookup *autonomousServiceLookup, resp *resource.ModifyPlanResponse) {
+	var configName types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("service_name"), &configName)...)
+	if resp.Diagnostics.HasError() || !configName.IsNull() || state == nil {
+		return
+	}
+
+	service, err := lookup.get(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Can not read service", err.Error())
+		return
+	}
+	if service == nil {
+		return
+	}
+	plan.ServiceName = types.StringValue(service.Name)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("service_name"), plan.ServiceName)...)
 }
@@ -71,15 +71,50 @@ func TestAutonomousResource(t *testing.T) {
 		           }
 				}`, serviceID, serviceName),
 			before: func(r *require.Assertions) {
+				expectRequest(getScalableServiceSuccess(t, serviceID))
+				expectRequest(getScalableServiceSuccess(t, serviceID))
 				expectRequest(getServiceByIDSuccess(t, serviceID))
 				expectRequest(setAutonomousResponse(t))
 				expectRequest(getServiceByIDSuccess(t, serviceID))
-				expectRequest(getAutonomousByServiceID(t, serviceID))
+				expectRequest(getAutonomousByServiceID(t, serviceID,
+					autonomous.NewAutoScaleDiskAction(200, true)))
 				expectRequest(deleteActionSuccessResponse(t))
 			},
 			checks: []resource.TestCheckFunc{
 				resource.TestCheckResourceAttr("skysql_autonomous.default", "id", serviceID),
 				resource.TestCheckResourceAttr("skysql_autonomous.default", "service_name", serviceName),
+				resource.TestCheckResourceAttr("skysql_autonomous.default", "auto_scale_disk.enabled", "true"),
+			},
+		},
+		{
+			name: "create with auto_scale_disk disabled",
+			testResource: fmt.Sprintf(`
+				resource "skysql_autonomous" "default" {
+					service_id = "%s"
+					service_name = "%s"
+					auto_scale_disk = {
+						enabled = false
+						max_storage_size_gbs = 200
+					}
+				}`, serviceID, serviceName),
+			before: func(r *require.Assertions) {
+				expectRequest(getScalableServiceSuccess(t, serviceID))
+				expectRequest(getScalableServiceSuccess(t, serviceID))
+				expectRequest(getServiceByIDSuccess(t, serviceID))
+				expectRequest(setAutonomousResponse(t, func(r *require.Assertions, payload *autonomous.SetAutonomousActionsRequest) {
+					r.Len(payload.Actions, 1)
+					r.False(payload.Actions[0].Enabled)
+				}))
+				// The paused action reads back unchanged, the plan after the
+				// apply is empty
+				expectRequest(getServiceByIDSuccess(t, serviceID))
+				expectRequest(getAutonomousByServiceID(t, serviceID,
+					autonomous.NewAutoScaleDiskAction(200, false)))
+				expectRequest(deleteActionSuccessResponse(t))
+			},
+			checks: []resource.TestCheckFunc{
+				resource.TestCheckResourceAttr("skysql_autonomous.default", "auto_scale_disk.enabled", "false"),
+				resource.TestCheckResourceAttr("skysql_autonomous.default", "auto_scale_disk.max_storage_size_gbs", "200"),
 			},
 		},
 		{
@@ -94,10 +129,13 @@ func TestAutonomousResource(t *testing.T) {
 					}
 				}`, serviceID, serviceName),
 			before: func(r *require.Assertions) {
+				expectRequest(getScalableServiceSuccess(t, serviceID))
+				expectRequest(getScalableServiceSuccess(t, serviceID))
 				expectRequest(getServiceByIDSuccess(t, serviceID))
 				expectRequest(setAutonomousResponse(t))
 				expectRequest(getServiceByIDSuccess(t, serviceID))
-				expectRequest(getAutonomousByServiceID(t, serviceID))
+				expectRequest(getAutonomousByServiceID(t, serviceID,
+					autonomous.NewAutoScaleNodesHorizontalAction(1, 2, true)))
 				expectRequest(deleteActionSuccessResponse(t))
 			},
 			checks: []resource.TestCheckFunc{
@@ -128,6 +166,31 @@ func TestAutonomousResource(t *testing.T) {
 				resource.TestCheckResourceAttr("skysql_autonomous.default", "service_name", serviceName),
 			},
 		},
+		{
+			name: "create with action",
+			testResource: fmt.Sprintf(`
+				resource "skysql_autonomous" "default" {
+					service_id = "%s"
+					service_name = "%s"
+					action {
+						group  = "autoScaleDisk"
+						params = jsonencode({ max_storage_size_gbs = 200 })
+					}
+				}`, serviceID, serviceName),
+			before: func(r *require.Assertions) {
+				expectRequest(getServiceByIDSuccess(t, serviceID))
+				expectRequest(setAutonomousResponse(t))
+				expectRequest(getServiceByIDSuccess(t, serviceID))
+				expectRequest(getAutonomousByServiceID(t, serviceID,
+					autonomous.NewAutoScaleDiskAction(200, true)))
+				expectRequest(deleteActionSuccessResponse(t))
+			},
+			checks: []resource.TestCheckFunc{
+				resource.TestCheckResourceAttr("skysql_autonomous.default", "action.#", "1"),
+				resource.TestCheckResourceAttr("skysql_autonomous.default", "action.0.id", groupToID[autonomous.AutoScaleDiskActionGroup]),
+				resource.TestCheckResourceAttr("skysql_autonomous.default", "action.0.enabled", "true"),
+			},
+		},
 	}
 	for _, test := range tests {
 		{
@@ -152,6 +215,122 @@ func TestAutonomousResource(t *testing.T) {
 	}
 }
 
+// TestAutonomousResourceServiceName checks that service_name defaults to the
+// name of the service and that renaming the service updates the actions.
+func TestAutonomousResourceServiceName(t *testing.T) {
+	configureOnce.Reset()
+
+	const serviceID = "dbdgf42002420"
+
+	testUrl, expectRequest, close := mockSkySQLAPI(t)
+	defer close()
+	os.Setenv("TF_SKYSQL_API_KEY", "[api-key]")
+	os.Setenv("TF_SKYSQL_API_BASE_URL", testUrl)
+
+	// The name of the service and the name the actions are registered under
+	serviceName := "test-service"
+	registeredName := ""
+
+	getService := func(w http.ResponseWriter, req *http.Request) {
+		r := require.New(t)
+		r.Equal(http.MethodGet, req.Method)
+		r.Equal("/provisioning/v1/services/"+serviceID, req.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		service := provisioning.Service{
+			ID:           serviceID,
+			Name:         serviceName,
+			Provider:     "gcp",
+			Architecture: "amd64",
+			Topology:     "es-replica",
+			Size:         "sky-2x8",
+			Nodes:        2,
+		}
+		service.StorageVolume.Size = 100
+		json.NewEncoder(w).Encode(service)
+	}
+	setActions := func(expectedName string) http.HandlerFunc {
+		return setAutonomousResponse(t, func(r *require.Assertions, payload *autonomous.SetAutonomousActionsRequest) {
+			r.Equal(expectedName, payload.ServiceName)
+			registeredName = payload.ServiceName
+		})
+	}
+	getActions := func(w http.ResponseWriter, req *http.Request) {
+		r := require.New(t)
+		r.Equal(http.MethodGet, req.Method)
+		r.Equal("/als/v1/actions", req.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		action := autonomous.NewAutoScaleDiskAction(200, true)
+		json.NewEncoder(w).Encode([]autonomous.ActionResponse{{
+			ID:          groupToID[action.Group],
+			Group:       action.Group,
+			Enabled:     action.Enabled,
+			Params:      action.Params,
+			ServiceID:   serviceID,
+			ServiceName: registeredName,
+		}})
+	}
+
+	expectRequest(versionsResponse(t))
+	// The plan and the apply validate the action, the service name is
+	// resolved by the create
+	expectRequest(getService)
+	expectRequest(getService)
+	expectRequest(getService)
+	expectRequest(setActions("test-service"))
+	// Each plan after the apply looks up the current name, around a refresh
+	expectRequest(getService)
+	expectRequest(getService)
+	expectRequest(getActions)
+	expectRequest(getService)
+	// The service is renamed, the refresh keeps the registered name and the
+	// plan and the apply plan the new one
+	expectRequest(getService)
+	expectRequest(getActions)
+	expectRequest(getService)
+	expectRequest(getService)
+	expectRequest(getService)
+	expectRequest(setActions("renamed-service"))
+	expectRequest(getService)
+	expectRequest(getService)
+	expectRequest(getActions)
+	expectRequest(getService)
+	expectRequest(deleteActionSuccessResponse(t))
+
+	config := fmt.Sprintf(`
+		resource "skysql_autonomous" "default" {
+			service_id = "%s"
+			auto_scale_disk = {
+				max_storage_size_gbs = 200
+			}
+		}`, serviceID)
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"skysql": providerserver.NewProtocol6WithError(New("")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("skysql_autonomous.default", "service_name", "test-service"),
+				),
+			},
+			{
+				PreConfig: func() {
+					serviceName = "renamed-service"
+				},
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("skysql_autonomous.default", "service_name", "renamed-service"),
+				),
+			},
+		},
+	})
+}
+
 func deleteActionSuccessResponse(t *testing.T) func(w http.ResponseWriter, req *http.Request) {
 	return func(w http.ResponseWriter, req *http.Request) {
 		r := require.New(t)
@@ -182,19 +361,35 @@ func TestModifyAutonomousResource(t *testing.T) {
 		w.WriteHeader(http.StatusOK)
 		json.NewEncoder(w).Encode([]provisioning.Version{})
 	})
+	// The plan and the apply validate the new actions against the service
+	expectRequest(getScalableServiceSuccess(t, serviceID))
+	expectRequest(getScalableServiceSuccess(t, serviceID))
 	expectRequest(getServiceByIDSuccess(t, serviceID))
 	expectRequest(setAutonomousResponse(t))
-	expectRequest(getServiceByIDSuccess(t, serviceID))
-	expectRequest(getAutonomousByServiceID(t, serviceID))
-	expectRequest(getServiceByIDSuccess(t, serviceID))
-	expectRequest(getAutonomousByServiceID(t, serviceID))
+	created := []autonomous.AutoScaleAction{
+		autonomous.NewAutoScaleDiskAction(200, true),
+		autonomous.NewAutoScaleNodesHorizontalAction(1, 2, true),
+	}
+	for i := 0; i < 2; i++ {
+		expectRequest(getServiceByIDSuccess(t, serviceID))
+		expectRequest(getAutonomousByServiceID(t, serviceID, created...))
+	}
+	for i := 0; i < 2; i++ {
+		expectRequest(getScalableServiceSuccess(t, serviceID))
+		expectRequest(getNodeSizesSuccess(t))
+	}
 	expectRequest(getServiceByIDSuccess(t, serviceID))
 	expectRequest(deleteActionSuccessResponse(t))
 	expectRequest(setAutonomousResponse(t))
-	expectRequest(getServiceByIDSuccess(t, serviceID))
-	expectRequest(getAutonomousByServiceID(t, serviceID))
-	expectRequest(getServiceByIDSuccess(t, serviceID))
-	expectRequest(getAutonomousByServiceID(t, serviceID))
+	// The vertical constructor takes the max size first
+	modified := []autonomous.AutoScaleAction{
+		autonomous.NewAutoScaleDiskAction(300, true),
+		autonomous.NewAutoScaleNodesVerticalAction("sky-4x32", "sky-2x8", true),
+	}
+	for i := 0; i < 2; i++ {
+		expectRequest(getServiceByIDSuccess(t, serviceID))
+		expectRequest(getAutonomousByServiceID(t, serviceID, modified...))
+	}
 	expectRequest(getServiceByIDSuccess(t, serviceID))
 	for i := 0; i < 2; i++ {
 		expectRequest(deleteActionSuccessResponse(t))
@@ -259,7 +454,7 @@ func TestModifyAutonomousResource(t *testing.T) {
 	})
 }
 
-func getAutonomousByServiceID(t *testing.T, serviceID string) func(w http.ResponseWriter, req *http.Request) {
+func getAutonomousByServiceID(t *testing.T, serviceID string, actions ...autonomous.AutoScaleAction) func(w http.ResponseWriter, req *http.Request) {
 	return func(w http.ResponseWriter, req *http.Request) {
 		r := require.New(t)
 		r.Equal(
@@ -268,7 +463,16 @@ func getAutonomousByServiceID(t *testing.T, serviceID string) func(w http.Respon
 		r.Equal("service_id="+serviceID, req.URL.RawQuery)
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
-		json.NewEncoder(w).Encode([]autonomous.ActionResponse{})
+		response := []autonomous.ActionResponse{}
+		for _, action := range actions {
+			response = append(response, autonomous.ActionResponse{
+				Group:   action.Group,
+				Enabled: action.Enabled,
+				Params:  action.Params,
+				ID:      groupToID[action.Group],
+			})
+		}
+		json.NewEncoder(w).Encode(response)
 	}
 }
 
@@ -278,7 +482,9 @@ var groupToID = map[string]string{
 	autonomous.AutoScaleNodesVerticalActionGroup:   uuid.New().String(),
 }
 
-func setAutonomousResponse(t *testing.T) func(w http.ResponseWriter, req *http.Request) {
+// setAutonomousResponse answers a set of actions, checks run against the
+// payload first.
+func setAutonomousResponse(t *testing.T, checks ...func(r *require.Assertions, payload *autonomous.SetAutonomousActionsRequest)) func(w http.ResponseWriter, req *http.Request) {
 	return func(w http.ResponseWriter, req *http.Request) {
 		r := require.New(t)
 		r.Equal(http.MethodPost, req.Method, fmt.Sprintf("unexpected request %s %s", req.Method, req.URL.Path))
@@ -288,6 +494,9 @@ func setAutonomousResponse(t *testing.T) func(w http.ResponseWriter, req *http.R
 		payload := &autonomous.SetAutonomousActionsRequest{}
 		err := json.NewDecoder(req.Body).Decode(payload)
 		r.NoError(err)
+		for _, check := range checks {
+			check(r, payload)
+		}
 
 		var response []autonomous.ActionResponse
 		for _, action := range payload.Actions {
@@ -306,6 +515,48 @@ func setAutonomousResponse(t *testing.T) func(w http.ResponseWriter, req *http.R
 	}
 }
 
+// getScalableServiceSuccess returns a service the autonomous actions of the
+// tests can scale.
+func getScalableServiceSuccess(t *testing.T, serviceID string) func(w http.ResponseWriter, req *http.Request) {
+	return func(w http.ResponseWriter, req *http.Request) {
+		r := require.New(t)
+		r.Equal(http.MethodGet, req.Method)
+		r.Equal("/provisioning/v1/services/"+serviceID, req.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		service := provisioning.Service{
+			ID:           serviceID,
+			Provider:     "gcp",
+			Architecture: "amd64",
+			Topology:     "es-replica",
+			Size:         "sky-2x8",
+			Nodes:        2,
+		}
+		service.StorageVolume.Size = 100
+		json.NewEncoder(w).Encode(service)
+	}
+}
+
+func getNodeSizesSuccess(t *testing.T) func(w http.ResponseWriter, req *http.Request) {
+	return func(w http.ResponseWriter, req *http.Request) {
+		r := require.New(t)
+		r.Equal(http.MethodGet, req.Method)
+		r.Equal("/provisioning/v1/sizes", req.URL.Path)
+		r.Equal("architecture=amd64&provider=gcp", req.URL.RawQuery)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(testNodeSizes)
+	}
+}
+
+var testNodeSizes = []provisioning.NodeSize{
+	{Name: "sky-2x4", CPU: "2 vCPU", RAM: "4 GB", Type: provisioning.NodeSizeTypeServer},
+	{Name: "sky-2x8", CPU: "2 vCPU", RAM: "8 GB", Type: provisioning.NodeSizeTypeServer},
+	{Name: "sky-4x16", CPU: "4 vCPU", RAM: "16 GB", Type: provisioning.NodeSizeTypeServer},
+	{Name: "sky-4x32", CPU: "4 vCPU", RAM: "32 GB", Type: provisioning.NodeSizeTypeServer},
+	{Name: "sky-2x4-proxy", CPU: "2 vCPU", RAM: "4 GB", Type: "proxy"},
+}
+
 func getServiceByIDSuccess(t *testing.T, serviceID string) func(w http.ResponseWriter, req *http.Request) {
 	return func(w http.ResponseWriter, req *http.Request) {
 		r := require.New(t)
@@ -0,0 +1,221 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql/provisioning"
+)
+
+// horizontalScalingUnsupportedTopologies are the topologies that run a single
+// node and can not scale horizontally.
+var horizontalScalingUnsupportedTopologies = []string{"es-single", "standalone"}
+
+// autonomousActionChanged reports whether a typed action is declared and new
+// or changed. Unchanged actions are not validated again, the service they
+// scale moves within their bounds.
+func autonomousActionChanged(plan types.Object, state *AutonomousResourceModel, prior func(*AutonomousResourceModel) types.Object) bool {
+	if plan.IsNull() || plan.IsUnknown() {
+		return false
+	}
+	if state == nil {
+		return true
+	}
+	previous := prior(state)
+	if previous.IsNull() || previous.IsUnknown() {
+		return true
+	}
+	// The computed id is unknown whenever another attribute changes
+	previousAttributes := previous.Attributes()
+	for name, value := range plan.Attributes() {
+		if name != "id" && !value.Equal(previousAttributes[name]) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateAutonomousPlan checks the bounds of the typed actions against the
+// service they scale, so a wrong bound fails the plan instead of the apply.
+func (r *AutonomousResource) validateAutonomousPlan(ctx context.Context, plan *AutonomousResourceModel, state *AutonomousResourceModel, lookup *autonomousServiceLookup, resp *resource.ModifyPlanResponse) {
+	disk := autonomousActionChanged(plan.AutoScaleDiskAction, state, func(m *AutonomousResourceModel) types.Object { return m.AutoScaleDiskAction })
+	horizontal := autonomousActionChanged(plan.AutoScaleNodesHorizontalAction, state, func(m *AutonomousResourceModel) types.Object { return m.AutoScaleNodesHorizontalAction })
+	vertical := autonomousActionChanged(plan.AutoScaleNodesVerticalAction, state, func(m *AutonomousResourceModel) types.Object { return m.AutoScaleNodesVerticalAction })
+	if !disk && !horizontal && !vertical {
+		return
+	}
+
+	service, err := lookup.get(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Can not read service", err.Error())
+		return
+	}
+	if service == nil {
+		return
+	}
+
+	if disk {
+		var action AutoScaleDiskAction
+		resp.Diagnostics.Append(plan.AutoScaleDiskAction.As(ctx, &action, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(validateAutoScaleDisk(service, action)...)
+	}
+
+	if horizontal {
+		var action AutoScaleNodesHorizontalAction
+		resp.Diagnostics.Append(plan.AutoScaleNodesHorizontalAction.As(ctx, &action, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(validateAutoScaleNodesHorizontal(service, action)...)
+	}
+
+	if vertical {
+		var action AutoScaleNodesVerticalAction
+		resp.Diagnostics.Append(plan.AutoScaleNodesVerticalAction.As(ctx, &action, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		sizes, err := r.client.GetNodeSizes(ctx,
+			skysql.WithProvider(service.Provider),
+			skysql.WithArchitecture(service.Architecture))
+		if err != nil {
+			resp.Diagnostics.AddError("Can not read node sizes", err.Error())
+			return
+		}
+		resp.Diagnostics.Append(validateAutoScaleNodesVertical(service, sizes, action)...)
+	}
+}
+
+// validateAutoScaleDisk checks that the storage can grow.
+func validateAutoScaleDisk(service *provisioning.Service, action AutoScaleDiskAction) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if action.MaxStorageSizeGBs.IsNull() || action.MaxStorageSizeGBs.IsUnknown() {
+		return diags
+	}
+	if action.MaxStorageSizeGBs.ValueInt64() <= int64(service.StorageVolume.Size) {
+		diags.AddAttributeError(
+			path.Root("auto_scale_disk").AtName("max_storage_size_gbs"),
+			"Invalid disk scaling",
+			fmt.Sprintf("max_storage_size_gbs must exceed the current storage of the service, %d GB.", service.StorageVolume.Size),
+		)
+	}
+	return diags
+}
+
+// validateAutoScaleNodesHorizontal checks that the topology scales
+// horizontally and that the bounds cover the current number of nodes.
+func validateAutoScaleNodesHorizontal(service *provisioning.Service, action AutoScaleNodesHorizontalAction) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if Contains(horizontalScalingUnsupportedTopologies, service.Topology) {
+		diags.AddAttributeError(
+			path.Root("auto_scale_nodes_horizontal"),
+			"Invalid horizontal scaling",
+			fmt.Sprintf("Services with topology %s can not scale horizontally.", service.Topology),
+		)
+		return diags
+	}
+
+	minNodes, maxNodes := action.MinNodes, action.MaxNodes
+	nodes := int64(service.Nodes)
+	if !minNodes.IsNull() && !minNodes.IsUnknown() && !maxNodes.IsNull() && !maxNodes.IsUnknown() &&
+		minNodes.ValueInt64() > maxNodes.ValueInt64() {
+		diags.AddAttributeError(
+			path.Root("auto_scale_nodes_horizontal").AtName("min_nodes"),
+			"Invalid horizontal scaling",
+			fmt.Sprintf("min_nodes (%d) must not exceed max_nodes (%d).", minNodes.ValueInt64(), maxNodes.ValueInt64()),
+		)
+		return diags
+	}
+	if !minNodes.IsNull() && !minNodes.IsUnknown() && minNodes.ValueInt64() > nodes {
+		diags.AddAttributeError(
+			path.Root("auto_scale_nodes_horizontal").AtName("min_nodes"),
+			"Invalid horizontal scaling",
+			fmt.Sprintf("min_nodes (%d) must not exceed the current number of nodes of the service, %d.", minNodes.ValueInt64(), nodes),
+		)
+	}
+	if !maxNodes.IsNull() && !maxNodes.IsUnknown() && maxNodes.ValueInt64() < nodes {
+		diags.AddAttributeError(
+			path.Root("auto_scale_nodes_horizontal").AtName("max_nodes"),
+			"Invalid horizontal scaling",
+			fmt.Sprintf("max_nodes (%d) must not be below the current number of nodes of the service, %d.", maxNodes.ValueInt64(), nodes),
+		)
+	}
+	return diags
+}
+
+// validateAutoScaleNodesVertical checks that both sizes exist for the
+// provider and architecture of the service and that min_node_size is not
+// larger than max_node_size.
+func validateAutoScaleNodesVertical(service *provisioning.Service, sizes []provisioning.NodeSize, action AutoScaleNodesVerticalAction) diag.Diagnostics {
+	var diags diag.Diagnostics
+	bySize := make(map[string]provisioning.NodeSize, len(sizes))
+	names := make([]string, 0, len(sizes))
+	for _, size := range sizes {
+		if size.Type != "" && size.Type != provisioning.NodeSizeTypeServer {
+			continue
+		}
+		bySize[size.Name] = size
+		names = append(names, size.Name)
+	}
+
+	lookup := func(attribute string, value types.String) (provisioning.NodeSize, bool) {
+		if value.IsNull() || value.IsUnknown() {
+			return provisioning.NodeSize{}, false
+		}
+		size, ok := bySize[value.ValueString()]
+		if !ok {
+			diags.AddAttributeError(
+				path.Root("auto_scale_nodes_vertical").AtName(attribute),
+				"Invalid vertical scaling",
+				fmt.Sprintf("%q is not a node size for provider %s and architecture %s. Valid sizes are: %v",
+					value.ValueString(), service.Provider, service.Architecture, names),
+			)
+		}
+		return size, ok
+	}
+	minSize, minOK := lookup("min_node_size", action.MinNodeSize)
+	maxSize, maxOK := lookup("max_node_size", action.MaxNodeSize)
+	if !minOK || !maxOK {
+		return diags
+	}
+
+	if compareNodeSizes(minSize, maxSize) > 0 {
+		diags.AddAttributeError(
+			path.Root("auto_scale_nodes_vertical").AtName("min_node_size"),
+			"Invalid vertical scaling",
+			fmt.Sprintf("min_node_size (%s) must not be larger than max_node_size (%s).", minSize.Name, maxSize.Name),
+		)
+	}
+	return diags
+}
+
+// compareNodeSizes orders sizes by CPU, then by memory, e.g. "4 vCPU" and
+// "16 GB". Sizes that can not be parsed compare as equal.
+func compareNodeSizes(a provisioning.NodeSize, b provisioning.NodeSize) int {
+	var aCPU, aRAM, bCPU, bRAM int
+	if _, err := fmt.Sscanf(a.CPU, "%d", &aCPU); err != nil {
+		return 0
+	}
+	if _, err := fmt.Sscanf(b.CPU, "%d", &bCPU); err != nil {
+		return 0
+	}
+	if aCPU != bCPU {
+		return aCPU - bCPU
+	}
+	if _, err := fmt.Sscanf(a.RAM, "%d", &aRAM); err != nil {
+		return 0
+	}
+	if _, err := fmt.Sscanf(b.RAM, "%d", &bRAM); err != nil {
+		return 0
+	}
+	return aRAM - bRAM
+}
@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql/provisioning"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateAutoScaleNodesHorizontal(t *testing.T) {
+	service := &provisioning.Service{Topology: "es-replica", Nodes: 3}
+	tests := []struct {
+		name     string
+		topology string
+		minNodes int64
+		maxNodes int64
+		err      string
+	}{
+		{name: "covers nodes", minNodes: 2, maxNodes: 5},
+		{name: "bounds equal nodes", minNodes: 3, maxNodes: 3},
+		{name: "min above max", minNodes: 4, maxNodes: 2, err: "min_nodes (4) must not exceed max_nodes (2)."},
+		{name: "min above nodes", minNodes: 4, maxNodes: 6, err: "min_nodes (4) must not exceed the current number of nodes of the service, 3."},
+		{name: "max below nodes", minNodes: 1, maxNodes: 2, err: "max_nodes (2) must not be below the current number of nodes of the service, 3."},
+		{name: "single node topology", topology: "es-single", minNodes: 1, maxNodes: 3, err: "Services with topology es-single can not scale horizontally."},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := require.New(t)
+			s := *service
+			if test.topology != "" {
+				s.Topology = test.topology
+			}
+			diags := validateAutoScaleNodesHorizontal(&s, AutoScaleNodesHorizontalAction{
+				MinNodes: types.Int64Value(test.minNodes),
+				MaxNodes: types.Int64Value(test.maxNodes),
+			})
+			if test.err == "" {
+				r.False(diags.HasError(), "%v", diags)
+				return
+			}
+			r.Equal(1, diags.ErrorsCount(), "%v", diags)
+			r.Equal(test.err, diags.Errors()[0].Detail())
+		})
+	}
+}
+
+func TestValidateAutoScaleNodesVertical(t *testing.T) {
+	service := &provisioning.Service{Provider: "gcp", Architecture: "amd64"}
+	sizes := []provisioning.NodeSize{
+		{Name: "sky-2x8", CPU: "2 vCPU", RAM: "8 GB", Type: provisioning.NodeSizeTypeServer},
+		{Name: "sky-4x16", CPU: "4 vCPU", RAM: "16 GB", Type: provisioning.NodeSizeTypeServer},
+		{Name: "sky-4x32", CPU: "4 vCPU", RAM: "32 GB", Type: provisioning.NodeSizeTypeServer},
+		{Name: "sky-2x4-proxy", CPU: "2 vCPU", RAM: "4 GB", Type: "proxy"},
+	}
+	tests := []struct {
+		name    string
+		minSize string
+		maxSize string
+		err     string
+	}{
+		{name: "ordered", minSize: "sky-2x8", maxSize: "sky-4x32"},
+		{name: "same cpu", minSize: "sky-4x16", maxSize: "sky-4x32"},
+		{name: "reversed", minSize: "sky-4x32", maxSize: "sky-4x16", err: "min_node_size (sky-4x32) must not be larger than max_node_size (sky-4x16)."},
+		{name: "unknown size", minSize: "sky-2x8", maxSize: "sky-64x512", err: `"sky-64x512" is not a node size for provider gcp and architecture amd64. Valid sizes are: [sky-2x8 sky-4x16 sky-4x32]`},
+		{name: "proxy size", minSize: "sky-2x4-proxy", maxSize: "sky-4x16", err: `"sky-2x4-proxy" is not a node size for provider gcp and architecture amd64. Valid sizes are: [sky-2x8 sky-4x16 sky-4x32]`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := require.New(t)
+			diags := validateAutoScaleNodesVertical(service, sizes, AutoScaleNodesVerticalAction{
+				MinNodeSize: types.StringValue(test.minSize),
+				MaxNodeSize: types.StringValue(test.maxSize),
+			})
+			if test.err == "" {
+				r.False(diags.HasError(), "%v", diags)
+				return
+			}
+			r.Equal(1, diags.ErrorsCount(), "%v", diags)
+			r.Equal(test.err, diags.Errors()[0].Detail())
+		})
+	}
+}
+
+func TestValidateAutoScaleDisk(t *testing.T) {
+	r := require.New(t)
+
+	service := &provisioning.Service{}
+	service.StorageVolume.Size = 100
+
+	r.False(validateAutoScaleDisk(service, AutoScaleDiskAction{MaxStorageSizeGBs: types.Int64Value(200)}).HasError())
+	r.True(validateAutoScaleDisk(service, AutoScaleDiskAction{MaxStorageSizeGBs: types.Int64Value(100)}).HasError())
+	r.False(validateAutoScaleDisk(service, AutoScaleDiskAction{MaxStorageSizeGBs: types.Int64Unknown()}).HasError())
+}
@@ -0,0 +1,294 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql/backup"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &BackupScheduleResource{}
+var _ resource.ResourceWithImportState = &BackupScheduleResource{}
+var _ resource.ResourceWithConfigure = &BackupScheduleResource{}
+
+// cronScheduleRegexp matches a cron expression with the five standard fields.
+var cronScheduleRegexp = regexp.MustCompile(`^\S+(\s+\S+){4}$`)
+
+func NewBackupScheduleResource() resource.Resource {
+	return &BackupScheduleResource{}
+}
+
+// BackupScheduleResource defines the resource implementation.
+type BackupScheduleResource struct {
+	client *skysql.Client
+}
+
+// BackupScheduleResourceModel describes the resource data model.
+type BackupScheduleResourceModel struct {
+	ID              types.String                `tfsdk:"id"`
+	ServiceID       types.String                `tfsdk:"service_id"`
+	Type            types.String                `tfsdk:"backup_type"`
+	Schedule        types.String                `tfsdk:"schedule"`
+	RetentionDays   types.Int64                 `tfsdk:"retention_days"`
+	ExternalStorage *BackupExternalStorageModel `tfsdk:"external_storage"`
+}
+
+// BackupExternalStorageModel describes the external storage target of the backups.
+type BackupExternalStorageModel struct {
+	BucketPath  types.String `tfsdk:"bucket_path"`
+	Credentials types.String `tfsdk:"credentials"`
+}
+
+func (r *BackupScheduleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_backup_schedule"
+}
+
+func (r *BackupScheduleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a recurring backup of a service",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the backup schedule",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"service_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the service to back up",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"backup_type": schema.StringAttribute{
+				Required:    true,
+				Description: "The type of the backup. Valid values are: full, incremental or binlog",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(backup.TypeFull, backup.TypeIncremental, backup.TypeBinlog),
+				},
+			},
+			"schedule": schema.StringAttribute{
+				Required:    true,
+				Description: "The cron expression the backup runs at, in UTC. For example 0 3 * * * runs the backup every day at 03:00",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(cronScheduleRegexp, "must be a cron expression with five fields"),
+				},
+			},
+			"retention_days": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The number of days the backups are kept. SkySQL applies its default retention when not set",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"external_storage": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "A bucket outside of SkySQL to write the backups to. The backups are kept in SkySQL storage when not set",
+				Attributes: map[string]schema.Attribute{
+					"bucket_path": schema.StringAttribute{
+						Required:    true,
+						Description: "The path of the bucket, for example s3://my-bucket/backups or gs://my-bucket/backups",
+					},
+					"credentials": schema.StringAttribute{
+						Required:    true,
+						Sensitive:   true,
+						Description: "The base64 encoded credentials SkySQL uses to write to the bucket. They are not returned by the API, changes made outside of Terraform are not detected",
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *BackupScheduleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*skysql.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *skysql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *BackupScheduleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data BackupScheduleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	schedule, err := r.client.CreateBackupSchedule(ctx, &backup.CreateScheduleRequest{
+		ServiceID:       data.ServiceID.ValueString(),
+		Type:            data.Type.ValueString(),
+		Schedule:        data.Schedule.ValueString(),
+		RetentionDays:   data.RetentionDays.ValueInt64(),
+		ExternalStorage: backupExternalStorage(data.ExternalStorage),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating backup schedule",
+			fmt.Sprintf("Unable to create backup schedule for service %q: %s", data.ServiceID.ValueString(), err))
+		return
+	}
+
+	tflog.Trace(ctx, "created backup schedule resource", map[string]interface{}{
+		"id":         schedule.ID,
+		"service_id": schedule.ServiceID,
+	})
+
+	setBackupScheduleState(&data, schedule)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BackupScheduleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data BackupScheduleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	schedule, err := r.client.GetBackupScheduleByID(ctx, data.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, skysql.ErrorServiceNotFound) {
+			tflog.Warn(ctx, "SkySQL backup schedule not found, removing from state", map[string]interface{}{
+				"id": data.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading backup schedule", err.Error())
+		return
+	}
+
+	setBackupScheduleState(&data, schedule)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BackupScheduleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan BackupScheduleResourceModel
+	var state BackupScheduleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	schedule, err := r.client.UpdateBackupSchedule(ctx, state.ID.ValueString(), &backup.UpdateScheduleRequest{
+		Schedule:        plan.Schedule.ValueString(),
+		RetentionDays:   plan.RetentionDays.ValueInt64(),
+		ExternalStorage: backupExternalStorage(plan.ExternalStorage),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating backup schedule", err.Error())
+		return
+	}
+
+	plan.ID = state.ID
+	setBackupScheduleState(&plan, schedule)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *BackupScheduleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data BackupScheduleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteBackupSchedule(ctx, data.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, skysql.ErrorServiceNotFound) {
+			tflog.Warn(ctx, "SkySQL backup schedule already deleted", map[string]interface{}{
+				"id": data.ID.ValueString(),
+			})
+			return
+		}
+		resp.Diagnostics.AddError("Error deleting backup schedule", err.Error())
+		return
+	}
+
+	tflog.Trace(ctx, "deleted backup schedule resource", map[string]interface{}{
+		"id": data.ID.ValueString(),
+	})
+}
+
+func (r *BackupScheduleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// backupExternalStorage converts the external storage of the model to its API
+// representation, nil when it is not set.
+func backupExternalStorage(data *BackupExternalStorageModel) *backup.ExternalStorage {
+	if data == nil {
+		return nil
+	}
+	return &backup.ExternalStorage{
+		BucketPath:  data.BucketPath.ValueString(),
+		Credentials: data.Credentials.ValueString(),
+	}
+}
+
+// setBackupScheduleState copies the backup schedule returned by the API into
+// the model. The credentials of the external storage are not returned by the
+// API and are kept from the model.
+func setBackupScheduleState(data *BackupScheduleResourceModel, schedule *backup.Schedule) {
+	data.ID = types.StringValue(schedule.ID)
+	data.ServiceID = types.StringValue(schedule.ServiceID)
+	data.Type = types.StringValue(schedule.Type)
+	data.Schedule = types.StringValue(schedule.Schedule)
+	data.RetentionDays = types.Int64Value(schedule.RetentionDays)
+
+	if schedule.ExternalStorage == nil || schedule.ExternalStorage.BucketPath == "" {
+		data.ExternalStorage = nil
+		return
+	}
+	credentials := types.StringNull()
+	if data.ExternalStorage != nil {
+		credentials = data.ExternalStorage.Credentials
+	}
+	data.ExternalStorage = &BackupExternalStorageModel{
+		BucketPath:  types.StringValue(schedule.ExternalStorage.BucketPath),
+		Credentials: credentials,
+	}
+}
@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql/backup"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupScheduleResource(t *testing.T) {
+	const serviceID = "dbdgf42002418"
+	const scheduleID = "sched-0123456789"
+
+	testUrl, expectRequest, close := mockSkySQLAPI(t)
+	defer close()
+	os.Setenv("TF_SKYSQL_API_KEY", "[api-key]")
+	os.Setenv("TF_SKYSQL_API_BASE_URL", testUrl)
+
+	r := require.New(t)
+
+	configureOnce.Reset()
+	var schedule *backup.Schedule
+
+	getSchedule := func(w http.ResponseWriter, req *http.Request) {
+		r.Equal(http.MethodGet, req.Method)
+		r.Equal("/skybackup/v1/backups/schedules/"+scheduleID, req.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		// The credentials are never returned
+		json.NewEncoder(w).Encode(&backup.Schedule{
+			ID:            schedule.ID,
+			ServiceID:     schedule.ServiceID,
+			Type:          schedule.Type,
+			Schedule:      schedule.Schedule,
+			RetentionDays: schedule.RetentionDays,
+			ExternalStorage: &backup.ExternalStorage{
+				BucketPath: schedule.ExternalStorage.BucketPath,
+			},
+		})
+	}
+
+	// Check API connectivity
+	expectRequest(versionsResponse(t))
+	// Create the schedule, the default retention is applied by the API
+	expectRequest(func(w http.ResponseWriter, req *http.Request) {
+		r.Equal(http.MethodPost, req.Method)
+		r.Equal("/skybackup/v1/backups/schedules", req.URL.Path)
+		payload := &backup.CreateScheduleRequest{}
+		err := json.NewDecoder(req.Body).Decode(payload)
+		r.NoError(err)
+		r.Equal(serviceID, payload.ServiceID)
+		r.Equal(backup.TypeFull, payload.Type)
+		r.Equal("0 3 * * *", payload.Schedule)
+		r.Zero(payload.RetentionDays)
+		r.NotNil(payload.ExternalStorage)
+		r.Equal("s3://my-bucket/backups", payload.ExternalStorage.BucketPath)
+		r.Equal("c2VjcmV0", payload.ExternalStorage.Credentials)
+		schedule = &backup.Schedule{
+			ID:              scheduleID,
+			ServiceID:       payload.ServiceID,
+			Type:            payload.Type,
+			Schedule:        payload.Schedule,
+			RetentionDays:   7,
+			ExternalStorage: payload.ExternalStorage,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(schedule)
+	})
+	// Refresh state and refresh before the update
+	for i := 0; i < 2; i++ {
+		expectRequest(getSchedule)
+	}
+	// Update the schedule and the retention in place
+	expectRequest(func(w http.ResponseWriter, req *http.Request) {
+		r.Equal(http.MethodPatch, req.Method)
+		r.Equal("/skybackup/v1/backups/schedules/"+scheduleID, req.URL.Path)
+		payload := &backup.UpdateScheduleRequest{}
+		err := json.NewDecoder(req.Body).Decode(payload)
+		r.NoError(err)
+		r.Equal("30 1 * * 0", payload.Schedule)
+		r.Equal(int64(30), payload.RetentionDays)
+		r.NotNil(payload.ExternalStorage)
+		r.Equal("c2VjcmV0", payload.ExternalStorage.Credentials)
+		schedule.Schedule = payload.Schedule
+		schedule.RetentionDays = payload.RetentionDays
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(schedule)
+	})
+	// Refresh state, then import and read the imported schedule
+	for i := 0; i < 2; i++ {
+		expectRequest(getSchedule)
+	}
+	expectRequest(func(w http.ResponseWriter, req *http.Request) {
+		r.Equal(http.MethodDelete, req.Method)
+		r.Equal("/skybackup/v1/backups/schedules/"+scheduleID, req.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	config := func(extra string) string {
+		return `
+			resource "skysql_backup_schedule" "nightly" {
+				service_id  = "` + serviceID + `"
+				backup_type = "full"
+				` + extra + `
+				external_storage = {
+					bucket_path = "s3://my-bucket/backups"
+					credentials = "c2VjcmV0"
+				}
+			}`
+	}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"skysql": providerserver.NewProtocol6WithError(New("")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config(`schedule = "0 3 * * *"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("skysql_backup_schedule.nightly", "id", scheduleID),
+					resource.TestCheckResourceAttr("skysql_backup_schedule.nightly", "backup_type", "full"),
+					resource.TestCheckResourceAttr("skysql_backup_schedule.nightly", "retention_days", "7"),
+					resource.TestCheckResourceAttr("skysql_backup_schedule.nightly", "external_storage.bucket_path", "s3://my-bucket/backups"),
+				),
+			},
+			{
+				Config: config(`
+				schedule       = "30 1 * * 0"
+				retention_days = 30`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("skysql_backup_schedule.nightly", "id", scheduleID),
+					resource.TestCheckResourceAttr("skysql_backup_schedule.nightly", "schedule", "30 1 * * 0"),
+					resource.TestCheckResourceAttr("skysql_backup_schedule.nightly", "retention_days", "30"),
+				),
+			},
+			{
+				ResourceName:      "skysql_backup_schedule.nightly",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The credentials are not returned by the API.
+				ImportStateVerifyIgnore: []string{"external_storage.credentials"},
+			},
+		},
+	})
+}
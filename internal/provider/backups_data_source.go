@@ -0,0 +1,229 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql/backup"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &BackupsDataSource{}
+
+func NewBackupsDataSource() datasource.DataSource {
+	return &BackupsDataSource{}
+}
+
+// BackupsDataSource defines the data source implementation.
+type BackupsDataSource struct {
+	client *skysql.Client
+}
+
+type BackupsDataSourceModel struct {
+	ServiceID     types.String  `tfsdk:"service_id"`
+	Status        types.String  `tfsdk:"status"`
+	Type          types.String  `tfsdk:"backup_type"`
+	StartedAfter  types.String  `tfsdk:"started_after"`
+	StartedBefore types.String  `tfsdk:"started_before"`
+	Backups       []BackupModel `tfsdk:"backups"`
+}
+
+type BackupModel struct {
+	ID               types.String `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	Type             types.String `tfsdk:"backup_type"`
+	Status           types.String `tfsdk:"status"`
+	Size             types.Int64  `tfsdk:"size"`
+	StartTime        types.String `tfsdk:"start_time"`
+	EndTime          types.String `tfsdk:"end_time"`
+	PointInTimeStart types.String `tfsdk:"point_in_time_start"`
+	PointInTimeEnd   types.String `tfsdk:"point_in_time_end"`
+}
+
+func (d *BackupsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_backups"
+}
+
+func (d *BackupsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the backups of a service",
+		Attributes: map[string]schema.Attribute{
+			"service_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the service to list the backups for",
+			},
+			"status": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list backups with this status. Valid values are: in_progress, succeeded or failed",
+				Validators: []validator.String{
+					stringvalidator.OneOf(backup.StatusInProgress, backup.StatusSucceeded, backup.StatusFailed),
+				},
+			},
+			"backup_type": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list backups of this type. Valid values are: full, incremental or binlog",
+				Validators: []validator.String{
+					stringvalidator.OneOf(backup.TypeFull, backup.TypeIncremental, backup.TypeBinlog),
+				},
+			},
+			"started_after": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list backups started at or after this time, in RFC 3339 format",
+			},
+			"started_before": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list backups started before this time, in RFC 3339 format",
+			},
+			"backups": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The backups of the service",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The ID of the backup",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the backup",
+						},
+						"backup_type": schema.StringAttribute{
+							Computed:    true,
+							Description: "The type of the backup",
+						},
+						"status": schema.StringAttribute{
+							Computed:    true,
+							Description: "The status of the backup",
+						},
+						"size": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The size of the backup in bytes",
+						},
+						"start_time": schema.StringAttribute{
+							Computed:    true,
+							Description: "The time the backup started, in RFC 3339 format",
+						},
+						"end_time": schema.StringAttribute{
+							Computed:    true,
+							Description: "The time the backup ended, in RFC 3339 format. Not set while the backup is in progress",
+						},
+						"point_in_time_start": schema.StringAttribute{
+							Computed:    true,
+							Description: "The earliest time the backup can restore a service to, in RFC 3339 format. Only set for binlog backups",
+						},
+						"point_in_time_end": schema.StringAttribute{
+							Computed:    true,
+							Description: "The latest time the backup can restore a service to, in RFC 3339 format. Only set for binlog backups",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *BackupsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*skysql.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *skysql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *BackupsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state BackupsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var filters []func(url.Values)
+	if !state.Status.IsNull() {
+		filters = append(filters, skysql.WithStatus(state.Status.ValueString()))
+	}
+	if !state.Type.IsNull() {
+		filters = append(filters, skysql.WithBackupType(state.Type.ValueString()))
+	}
+	if !state.StartedAfter.IsNull() {
+		startedAfter, err := time.Parse(time.RFC3339, state.StartedAfter.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("started_after"), "Invalid started_after value", err.Error())
+		}
+		filters = append(filters, skysql.WithStartedAfter(startedAfter))
+	}
+	if !state.StartedBefore.IsNull() {
+		startedBefore, err := time.Parse(time.RFC3339, state.StartedBefore.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("started_before"), "Invalid started_before value", err.Error())
+		}
+		filters = append(filters, skysql.WithStartedBefore(startedBefore))
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Listing backups", map[string]interface{}{
+		"service_id": state.ServiceID.ValueString(),
+	})
+
+	backups, err := d.client.GetBackups(ctx, state.ServiceID.ValueString(), filters...)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read SkySQL backups", err.Error())
+		return
+	}
+
+	state.Backups = make([]BackupModel, 0, len(backups))
+	for _, b := range backups {
+		state.Backups = append(state.Backups, BackupModel{
+			ID:               types.StringValue(b.ID),
+			Name:             types.StringValue(b.Name),
+			Type:             types.StringValue(b.Type),
+			Status:           types.StringValue(b.Status),
+			Size:             types.Int64Value(b.Size),
+			StartTime:        timeValue(b.StartTime),
+			EndTime:          timeValue(b.EndTime),
+			PointInTimeStart: timeValue(b.PointInTimeStart),
+			PointInTimeEnd:   timeValue(b.PointInTimeEnd),
+		})
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// timeValue formats a time in RFC 3339, null when it is not set.
+func timeValue(t *time.Time) types.String {
+	if t == nil || t.IsZero() {
+		return types.StringNull()
+	}
+	return types.StringValue(t.UTC().Format(time.RFC3339))
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the CIDR type and value fully satisfy framework interfaces
+var _ basetypes.StringTypable = CIDRType{}
+var _ basetypes.StringValuableWithSemanticEquals = CIDRValue{}
+
+// CIDRType is the type of allow list addresses. Addresses of the same
+// network, e.g. 1.2.3.4 and 1.2.3.4/32, are semantically equal, so the form
+// the API returns does not show up as a difference.
+type CIDRType struct {
+	basetypes.StringType
+}
+
+func (t CIDRType) String() string {
+	return "CIDRType"
+}
+
+func (t CIDRType) ValueType(ctx context.Context) attr.Value {
+	return CIDRValue{}
+}
+
+func (t CIDRType) Equal(o attr.Type) bool {
+	other, ok := o.(CIDRType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t CIDRType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return CIDRValue{StringValue: in}, nil
+}
+
+func (t CIDRType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+	return stringValuable, nil
+}
+
+// CIDRValue is an allow list address, an IP address or a CIDR range.
+type CIDRValue struct {
+	basetypes.StringValue
+}
+
+// NewCIDRValue returns a known allow list address.
+func NewCIDRValue(value string) CIDRValue {
+	return CIDRValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+func (v CIDRValue) Type(ctx context.Context) attr.Type {
+	return CIDRType{}
+}
+
+func (v CIDRValue) Equal(o attr.Value) bool {
+	other, ok := o.(CIDRValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals returns true when both addresses describe the same
+// network. Invalid addresses are only equal to themselves.
+func (v CIDRValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	newValue, ok := newValuable.(CIDRValue)
+	if !ok {
+		return false, nil
+	}
+	return sameAllowListIP(v.ValueString(), newValue.ValueString()), nil
+}
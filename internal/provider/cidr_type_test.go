@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalCIDR(t *testing.T) {
+	tests := []struct {
+		ip        string
+		canonical string
+		err       string
+	}{
+		{ip: "1.2.3.4", canonical: "1.2.3.4/32"},
+		{ip: "1.2.3.4/32", canonical: "1.2.3.4/32"},
+		{ip: "10.0.0.0/8", canonical: "10.0.0.0/8"},
+		{ip: "2001:db8::1", canonical: "2001:db8::1/128"},
+		{ip: "2001:DB8:0:0::/32", canonical: "2001:db8::/32"},
+		{ip: "10.0.0.5/24", err: `"10.0.0.5/24" has host bits set, the range starts at 10.0.0.0/24`},
+		{ip: "10.0.0.0/33", err: `"10.0.0.0/33" is not a valid CIDR range`},
+		{ip: "fe80::1%eth0", err: `"fe80::1%eth0" is neither an IP address nor a CIDR range`},
+		{ip: "localhost", err: `"localhost" is neither an IP address nor a CIDR range`},
+	}
+	for _, test := range tests {
+		t.Run(test.ip, func(t *testing.T) {
+			canonical, err := canonicalCIDR(test.ip)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.canonical, canonical)
+		})
+	}
+}
+
+func TestCIDRValueSemanticEquals(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	equal, diags := NewCIDRValue("1.2.3.4").StringSemanticEquals(ctx, NewCIDRValue("1.2.3.4/32"))
+	r.False(diags.HasError())
+	r.True(equal)
+
+	equal, _ = NewCIDRValue("2001:db8:0::1").StringSemanticEquals(ctx, NewCIDRValue("2001:db8::1/128"))
+	r.True(equal)
+
+	equal, _ = NewCIDRValue("1.2.3.4").StringSemanticEquals(ctx, NewCIDRValue("1.2.3.0/24"))
+	r.False(equal)
+}
+
+func TestCollapsedAllowListEntries(t *testing.T) {
+	r := require.New(t)
+
+	collapsed := collapsedAllowListEntries([]string{
+		"10.0.1.7",
+		"10.0.0.0/16",
+		"192.0.2.1/32",
+		"192.0.2.1",
+		"2001:db8::/32",
+		"2001:db8::7",
+		"not an address",
+	})
+	r.Equal(map[int]bool{0: true, 3: true, 5: true}, collapsed)
+}
+
+func TestAllowListOverlapValidator(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	entry := func(ip string) attr.Value {
+		return types.ObjectValueMust(allowListElementType.AttrTypes, map[string]attr.Value{
+			"ip":         NewCIDRValue(ip),
+			"comment":    types.StringNull(),
+			"expires_at": types.StringNull(),
+		})
+	}
+	list := types.ListValueMust(allowListElementType, []attr.Value{
+		entry("10.0.0.0/16"),
+		entry("10.0.1.7"),
+		entry("192.0.2.1"),
+		entry("192.0.2.1/32"),
+		entry("203.0.113.0/24"),
+	})
+
+	resp := &validator.ListResponse{}
+	allowListOverlapValidator{}.ValidateList(ctx, validator.ListRequest{
+		Path:        path.Root("allow_list"),
+		ConfigValue: list,
+	}, resp)
+
+	r.False(resp.Diagnostics.HasError())
+	r.Equal(2, resp.Diagnostics.WarningsCount())
+	r.Equal("Overlapping allow list entry", resp.Diagnostics.Warnings()[0].Summary())
+	r.Equal("Duplicate allow list entry", resp.Diagnostics.Warnings()[1].Summary())
+}
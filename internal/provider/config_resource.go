@@ -437,5 +437,10 @@ func (r *ConfigResource) Delete(ctx context.Context, req resource.DeleteRequest,
 }
 
 func (r *ConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	id, err := resolveConfigImportID(ctx, r.client, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Can not import resource", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql"
+)
+
+// Prefixes of the import IDs that refer to an object by name instead of ID.
+const (
+	importIDNamePrefix    = "name:"
+	importIDProjectPrefix = "project:"
+)
+
+// resolveServiceImportID returns the service ID for an import ID. Besides a
+// plain service ID it accepts name:<service-name> and
+// project:<project-id>/<service-name>, resolved through the service list.
+func resolveServiceImportID(ctx context.Context, client *skysql.Client, importID string) (string, error) {
+	var projectID, name string
+	switch {
+	case strings.HasPrefix(importID, importIDNamePrefix):
+		name = strings.TrimPrefix(importID, importIDNamePrefix)
+	case strings.HasPrefix(importID, importIDProjectPrefix):
+		var found bool
+		projectID, name, found = strings.Cut(strings.TrimPrefix(importID, importIDProjectPrefix), "/")
+		if !found || projectID == "" {
+			return "", fmt.Errorf("invalid import ID %q, expected project:<project-id>/<service-name>", importID)
+		}
+	default:
+		return importID, nil
+	}
+	if name == "" {
+		return "", fmt.Errorf("invalid import ID %q, the service name is empty", importID)
+	}
+
+	filters := []func(url.Values){skysql.WithName(name)}
+	if projectID != "" {
+		filters = append(filters, skysql.WithProjectID(projectID))
+	}
+	services, err := client.GetServices(ctx, filters...)
+	if err != nil {
+		return "", fmt.Errorf("can not list services: %w", err)
+	}
+
+	var ids []string
+	for _, service := range services {
+		// The list is filtered by the API already, services that do not
+		// report a project are trusted to belong to the requested one.
+		if service.Name != name || (projectID != "" && service.ProjectID != "" && service.ProjectID != projectID) {
+			continue
+		}
+		ids = append(ids, service.ID)
+	}
+
+	switch len(ids) {
+	case 0:
+		if projectID != "" {
+			return "", fmt.Errorf("no service named %q was found in project %q", name, projectID)
+		}
+		return "", fmt.Errorf("no service named %q was found", name)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("service name %q is ambiguous, it matches services %s. "+
+			"Import by service ID or by project:<project-id>/%s instead", name, strings.Join(ids, ", "), name)
+	}
+}
+
+// resolveConfigImportID returns the configuration ID for an import ID. Besides
+// a plain configuration ID it accepts name:<config-name>, resolved through the
+// configuration list. Public configurations are never matched by name.
+func resolveConfigImportID(ctx context.Context, client *skysql.Client, importID string) (string, error) {
+	if !strings.HasPrefix(importID, importIDNamePrefix) {
+		return importID, nil
+	}
+	name := strings.TrimPrefix(importID, importIDNamePrefix)
+	if name == "" {
+		return "", fmt.Errorf("invalid import ID %q, the configuration name is empty", importID)
+	}
+
+	configs, err := client.GetConfigs(ctx, skysql.WithName(name))
+	if err != nil {
+		return "", fmt.Errorf("can not list configurations: %w", err)
+	}
+
+	var ids []string
+	for _, config := range configs {
+		if config.Name == name && !config.Public {
+			ids = append(ids, config.ID)
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("no configuration named %q was found", name)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("configuration name %q is ambiguous, it matches configurations %s. "+
+			"Import by configuration ID instead", name, strings.Join(ids, ", "))
+	}
+}
@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql/provisioning"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveServiceImportID(t *testing.T) {
+	testUrl, expectRequest, close := mockSkySQLAPI(t)
+	defer close()
+
+	r := require.New(t)
+	ctx := context.Background()
+	client := skysql.New(testUrl, "[api-key]", "")
+
+	listServices := func(query string, services ...provisioning.Service) http.HandlerFunc {
+		return func(w http.ResponseWriter, req *http.Request) {
+			r.Equal(http.MethodGet, req.Method)
+			r.Equal("/provisioning/v1/services", req.URL.Path)
+			r.Equal(query, req.URL.RawQuery)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(services)
+		}
+	}
+
+	// A plain ID is used as is
+	id, err := resolveServiceImportID(ctx, client, "dbdgf42002418")
+	r.NoError(err)
+	r.Equal("dbdgf42002418", id)
+
+	expectRequest(listServices("name=my-db",
+		provisioning.Service{ID: "dbdgf42002418", Name: "my-db"},
+		provisioning.Service{ID: "dbdgf42002419", Name: "my-db-2"},
+	))
+	id, err = resolveServiceImportID(ctx, client, "name:my-db")
+	r.NoError(err)
+	r.Equal("dbdgf42002418", id)
+
+	expectRequest(listServices("name=my-db",
+		provisioning.Service{ID: "dbdgf42002418", Name: "my-db", ProjectID: "project-1"},
+		provisioning.Service{ID: "dbdgf42002419", Name: "my-db", ProjectID: "project-2"},
+	))
+	_, err = resolveServiceImportID(ctx, client, "name:my-db")
+	r.ErrorContains(err, `service name "my-db" is ambiguous, it matches services dbdgf42002418, dbdgf42002419`)
+
+	expectRequest(listServices("name=my-db&project_id=project-2",
+		provisioning.Service{ID: "dbdgf42002419", Name: "my-db", ProjectID: "project-2"},
+	))
+	id, err = resolveServiceImportID(ctx, client, "project:project-2/my-db")
+	r.NoError(err)
+	r.Equal("dbdgf42002419", id)
+
+	expectRequest(listServices("name=my-db&project_id=project-3"))
+	_, err = resolveServiceImportID(ctx, client, "project:project-3/my-db")
+	r.ErrorContains(err, `no service named "my-db" was found in project "project-3"`)
+
+	_, err = resolveServiceImportID(ctx, client, "project:my-db")
+	r.ErrorContains(err, "expected project:<project-id>/<service-name>")
+
+	_, err = resolveServiceImportID(ctx, client, "name:")
+	r.ErrorContains(err, "the service name is empty")
+}
+
+func TestResolveConfigImportID(t *testing.T) {
+	testUrl, expectRequest, close := mockSkySQLAPI(t)
+	defer close()
+
+	r := require.New(t)
+	ctx := context.Background()
+	client := skysql.New(testUrl, "[api-key]", "")
+
+	listConfigs := func(configs ...provisioning.Config) http.HandlerFunc {
+		return func(w http.ResponseWriter, req *http.Request) {
+			r.Equal(http.MethodGet, req.Method)
+			r.Equal("/provisioning/v1/configs", req.URL.Path)
+			r.Equal("name=my-config", req.URL.RawQuery)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(configs)
+		}
+	}
+
+	id, err := resolveConfigImportID(ctx, client, "cfg-123")
+	r.NoError(err)
+	r.Equal("cfg-123", id)
+
+	// Public configurations are not matched by name
+	expectRequest(listConfigs(
+		provisioning.Config{ID: "cfg-public", Name: "my-config", Public: true},
+		provisioning.Config{ID: "cfg-123", Name: "my-config"},
+	))
+	id, err = resolveConfigImportID(ctx, client, "name:my-config")
+	r.NoError(err)
+	r.Equal("cfg-123", id)
+
+	expectRequest(listConfigs(
+		provisioning.Config{ID: "cfg-123", Name: "my-config"},
+		provisioning.Config{ID: "cfg-456", Name: "my-config"},
+	))
+	_, err = resolveConfigImportID(ctx, client, "name:my-config")
+	r.ErrorContains(err, `configuration name "my-config" is ambiguous, it matches configurations cfg-123, cfg-456`)
+
+	expectRequest(listConfigs())
+	_, err = resolveConfigImportID(ctx, client, "name:my-config")
+	r.ErrorContains(err, `no configuration named "my-config" was found`)
+}
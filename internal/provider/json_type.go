@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the JSON type and value fully satisfy framework interfaces
+var _ basetypes.StringTypable = JSONType{}
+var _ basetypes.StringValuableWithSemanticEquals = JSONValue{}
+
+// JSONType is the type of JSON-encoded strings. Documents that only differ in
+// formatting or key order, e.g. as the API returns them, are semantically
+// equal, so they do not show up as a difference.
+type JSONType struct {
+	basetypes.StringType
+}
+
+func (t JSONType) String() string {
+	return "JSONType"
+}
+
+func (t JSONType) ValueType(ctx context.Context) attr.Value {
+	return JSONValue{}
+}
+
+func (t JSONType) Equal(o attr.Type) bool {
+	other, ok := o.(JSONType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t JSONType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return JSONValue{StringValue: in}, nil
+}
+
+func (t JSONType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+	return stringValuable, nil
+}
+
+// JSONValue is a JSON-encoded string.
+type JSONValue struct {
+	basetypes.StringValue
+}
+
+// NewJSONValue returns a known JSON-encoded string.
+func NewJSONValue(value string) JSONValue {
+	return JSONValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+func (v JSONValue) Type(ctx context.Context) attr.Type {
+	return JSONType{}
+}
+
+func (v JSONValue) Equal(o attr.Value) bool {
+	other, ok := o.(JSONValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals returns true when both strings decode to the same JSON
+// document. Invalid JSON is only equal to itself.
+func (v JSONValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	newValue, ok := newValuable.(JSONValue)
+	if !ok {
+		return false, nil
+	}
+	return sameJSON(v.ValueString(), newValue.ValueString()), nil
+}
+
+// sameJSON returns whether a and b decode to the same JSON document.
+func sameJSON(a string, b string) bool {
+	if a == b {
+		return true
+	}
+	var x, y interface{}
+	if err := json.Unmarshal([]byte(a), &x); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(b), &y); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(x, y)
+}
+
+type jsonValidator struct{}
+
+// Description returns a plain text description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (v jsonValidator) Description(ctx context.Context) string {
+	return "value must be a JSON-encoded string, e.g. the result of jsonencode()"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (v jsonValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be a JSON-encoded string, e.g. the result of `jsonencode()`"
+}
+
+// ValidateString Validate runs the main validation logic of the validator, reading configuration data out of `req` and updating `resp` with diagnostics.
+func (v jsonValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	if !json.Valid([]byte(req.ConfigValue.ValueString())) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid JSON",
+			fmt.Sprintf("%q must be a JSON-encoded string, e.g. the result of jsonencode()", req.ConfigValue.ValueString()),
+		)
+	}
+}
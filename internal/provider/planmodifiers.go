@@ -3,7 +3,9 @@ package provider
 import (
 	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -49,3 +51,33 @@ func boolDefault(defaultValue bool) planmodifier.Bool {
 		Default: defaultValue,
 	}
 }
+
+// boolRequiresReplaceIfValueChanged requires a replacement when the value
+// changes, a null value is treated as false. Unlike RequiresReplace it does
+// not replace the resource when an explicit false replaces a null state, e.g.
+// after an import.
+func boolRequiresReplaceIfValueChanged() planmodifier.Bool {
+	return boolplanmodifier.RequiresReplaceIf(
+		func(_ context.Context, req planmodifier.BoolRequest, resp *boolplanmodifier.RequiresReplaceIfFuncResponse) {
+			if !req.PlanValue.IsUnknown() && req.PlanValue.ValueBool() != req.StateValue.ValueBool() {
+				resp.RequiresReplace = true
+			}
+		},
+		"If the value of this attribute changes, Terraform will destroy and recreate the resource.",
+		"If the value of this attribute changes, Terraform will destroy and recreate the resource.",
+	)
+}
+
+// stringRequiresReplaceIfValueChanged requires a replacement when the value
+// changes, a null value is treated as an empty string.
+func stringRequiresReplaceIfValueChanged() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			if !req.PlanValue.IsUnknown() && req.PlanValue.ValueString() != req.StateValue.ValueString() {
+				resp.RequiresReplace = true
+			}
+		},
+		"If the value of this attribute changes, Terraform will destroy and recreate the resource.",
+		"If the value of this attribute changes, Terraform will destroy and recreate the resource.",
+	)
+}
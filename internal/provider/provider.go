@@ -147,8 +147,12 @@ func (p *skySQLProvider) Resources(ctx context.Context) []func() resource.Resour
 	return []func() resource.Resource{
 		NewServiceResource,
 		NewServiceAllowListResource,
+		NewAllowListEntryResource,
+		NewAllowListSetResource,
 		NewAutonomousResource,
 		NewConfigResource,
+		NewBackupScheduleResource,
+		NewServiceRestoreResource,
 	}
 }
 
@@ -159,6 +163,7 @@ func (p *skySQLProvider) DataSources(ctx context.Context) []func() datasource.Da
 		NewServiceDataSource,
 		NewCredentialsDataSource,
 		NewAvailabilityZonesDataSource,
+		NewBackupsDataSource,
 	}
 }
 
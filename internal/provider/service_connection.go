@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql/provisioning"
+)
+
+const (
+	portPurposeReadWrite = "readwrite"
+	portPurposeReadOnly  = "readonly"
+)
+
+var servicePortElementType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"name":    types.StringType,
+		"port":    types.Int64Type,
+		"purpose": types.StringType,
+	},
+}
+
+// primaryServiceEndpoint returns the endpoint named primary, or the first one
+// when none of the endpoints carries that name.
+func primaryServiceEndpoint(endpoints []provisioning.Endpoint) *provisioning.Endpoint {
+	for i := range endpoints {
+		if endpoints[i].Name == primaryEndpointName {
+			return &endpoints[i]
+		}
+	}
+	if len(endpoints) > 0 {
+		return &endpoints[0]
+	}
+	return nil
+}
+
+// setConnectionState fills the computed connection details of the service:
+// the ports of the primary endpoint, the outbound IPs and the connection URIs.
+func setConnectionState(ctx context.Context, data *ServiceResourceModel, service *provisioning.Service) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	ports := make([]ServiceResourceNamedPortModel, 0)
+	var readWritePort, readOnlyPort int64
+	if endpoint := primaryServiceEndpoint(service.Endpoints); endpoint != nil {
+		for _, port := range endpoint.Ports {
+			ports = append(ports, ServiceResourceNamedPortModel{
+				Name:    types.StringValue(port.Name),
+				Port:    types.Int64Value(int64(port.Port)),
+				Purpose: types.StringValue(port.Purpose),
+			})
+			switch {
+			case port.Purpose == portPurposeReadWrite && readWritePort == 0:
+				readWritePort = int64(port.Port)
+			case port.Purpose == portPurposeReadOnly && readOnlyPort == 0:
+				readOnlyPort = int64(port.Port)
+			}
+		}
+	}
+
+	var d diag.Diagnostics
+	data.Ports, d = types.ListValueFrom(ctx, servicePortElementType, ports)
+	diags.Append(d...)
+
+	outboundIps := service.OutboundIps
+	if outboundIps == nil {
+		outboundIps = []string{}
+	}
+	data.OutboundIps, d = types.ListValueFrom(ctx, types.StringType, outboundIps)
+	diags.Append(d...)
+
+	data.ReadWritePort = types.Int64Null()
+	if readWritePort > 0 {
+		data.ReadWritePort = types.Int64Value(readWritePort)
+	}
+	data.ReadOnlyPort = types.Int64Null()
+	if readOnlyPort > 0 {
+		data.ReadOnlyPort = types.Int64Value(readOnlyPort)
+	}
+
+	data.ConnectionURIs, d = types.MapValueFrom(ctx, types.StringType,
+		serviceConnectionURIs(service.FQDN, readWritePort, readOnlyPort, service.SSLEnabled))
+	diags.Append(d...)
+
+	return diags
+}
+
+// serviceConnectionURIs builds mysql, JDBC and ODBC connection strings for the
+// read-write and read-only ports. Credentials are never part of the URIs, the
+// keys of the read-only port carry a _readonly suffix.
+func serviceConnectionURIs(fqdn string, readWritePort, readOnlyPort int64, sslEnabled bool) map[string]string {
+	uris := map[string]string{}
+	if fqdn == "" {
+		// The FQDN is only known once the service is ready.
+		return uris
+	}
+
+	add := func(suffix string, port int64) {
+		if port == 0 {
+			return
+		}
+		mysql := fmt.Sprintf("mysql://%s:%d/", fqdn, port)
+		jdbc := fmt.Sprintf("jdbc:mariadb://%s:%d/", fqdn, port)
+		odbc := fmt.Sprintf("DRIVER={MariaDB ODBC 3.1 Driver};SERVER=%s;PORT=%d", fqdn, port)
+		if sslEnabled {
+			mysql += "?ssl-mode=REQUIRED"
+			jdbc += "?sslMode=verify-full"
+			odbc += ";FORCETLS=1;SSLVERIFY=1"
+		}
+		uris["mysql"+suffix] = mysql
+		uris["jdbc"+suffix] = jdbc
+		uris["odbc"+suffix] = odbc
+	}
+	add("", readWritePort)
+	add("_readonly", readOnlyPort)
+	return uris
+}
+
+// planPrimaryEndpointMechanism returns the mechanism of the primary endpoint,
+// either from the endpoint blocks or from the flat endpoint_mechanism.
+func planPrimaryEndpointMechanism(ctx context.Context, data *ServiceResourceModel) types.String {
+	if !hasEndpointBlocks(data) {
+		return data.Mechanism
+	}
+	endpoints, diags := serviceEndpoints(ctx, data)
+	if diags.HasError() || len(endpoints) == 0 {
+		return types.StringUnknown()
+	}
+	for _, endpoint := range endpoints {
+		if endpoint.Name.ValueString() == primaryEndpointName {
+			return endpoint.Mechanism
+		}
+	}
+	return endpoints[0].Mechanism
+}
+
+// modifyConnectionPlan marks the connection details as unknown when the plan
+// changes the primary endpoint mechanism or ssl_enabled, they are kept from
+// state otherwise.
+func modifyConnectionPlan(ctx context.Context, plan *ServiceResourceModel, state *ServiceResourceModel, resp *resource.ModifyPlanResponse) {
+	if state == nil || resp.Diagnostics.HasError() {
+		return
+	}
+
+	mechanism := planPrimaryEndpointMechanism(ctx, plan)
+	mechanismChanged := mechanism.IsUnknown() ||
+		mechanism.ValueString() != planPrimaryEndpointMechanism(ctx, state).ValueString()
+	sslChanged := plan.SSLEnabled.IsUnknown() || !plan.SSLEnabled.Equal(state.SSLEnabled)
+
+	if mechanismChanged {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("ports"), types.ListUnknown(servicePortElementType))...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("outbound_ips"), types.ListUnknown(types.StringType))...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("readwrite_port"), types.Int64Unknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("readonly_port"), types.Int64Unknown())...)
+	}
+	if mechanismChanged || sslChanged {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("connection_uris"), types.MapUnknown(types.StringType))...)
+	}
+}
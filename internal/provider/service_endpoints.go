@@ -0,0 +1,557 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql/provisioning"
+)
+
+// primaryEndpointName is the name SkySQL gives to the endpoint that is created
+// together with a service.
+const primaryEndpointName = "primary"
+
+// ServiceEndpointModel is a named endpoint of a service
+type ServiceEndpointModel struct {
+	Name            types.String `tfsdk:"name"`
+	Mechanism       types.String `tfsdk:"mechanism"`
+	Visibility      types.String `tfsdk:"visibility"`
+	AllowedAccounts types.List   `tfsdk:"allowed_accounts"`
+	AllowList       types.List   `tfsdk:"allow_list"`
+	EndpointService types.String `tfsdk:"endpoint_service"`
+}
+
+var serviceEndpointElementType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"name":             types.StringType,
+		"mechanism":        types.StringType,
+		"visibility":       types.StringType,
+		"allowed_accounts": types.ListType{ElemType: types.StringType},
+		"allow_list":       types.ListType{ElemType: allowListElementType},
+		"endpoint_service": types.StringType,
+	},
+}
+
+var serviceEndpointBlock = schema.ListNestedBlock{
+	Description: "A named endpoint of the service. Endpoints are matched by name, endpoints that are not declared are left unmanaged. " +
+		"The first block describes the `primary` endpoint that is created together with the service. " +
+		"Can not be combined with `endpoint_mechanism`, `endpoint_allowed_accounts` and `allow_list`.",
+	NestedObject: schema.NestedBlockObject{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the endpoint, e.g. primary",
+			},
+			"mechanism": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The endpoint mechanism to use. Valid values are: privateconnect or nlb",
+				Validators: []validator.String{
+					stringvalidator.OneOf(append([]string{"nlb"}, privateConnectMechanisms...)...),
+				},
+			},
+			"visibility": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The visibility of the endpoint. Valid values are: public or private. Defaults to private for privateconnect and public for nlb",
+				Validators: []validator.String{
+					stringvalidator.OneOf(visibilityPublic, visibilityPrivate),
+				},
+			},
+			"allowed_accounts": schema.ListAttribute{
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The list of cloud accounts (aws, azure, or gcp projects) that are allowed to access the endpoint. Works only with `privateconnect` endpoint mechanism",
+			},
+			"allow_list": schema.ListNestedAttribute{
+				Optional:     true,
+				Computed:     true,
+				Description:  "The list of IP addresses with comments to allow access to the endpoint",
+				NestedObject: serviceAllowListNestedObject,
+				Validators: []validator.List{
+					allowListOverlapValidator{},
+				},
+			},
+			"endpoint_service": schema.StringAttribute{
+				Computed:    true,
+				Description: "The endpoint service name of the endpoint, when mechanism is a privateconnect.",
+			},
+		},
+	},
+}
+
+// hasEndpointBlocks reports whether the endpoints are managed through endpoint
+// blocks rather than the flat endpoint_* attributes.
+func hasEndpointBlocks(data *ServiceResourceModel) bool {
+	return !data.Endpoints.IsNull() && !data.Endpoints.IsUnknown() && len(data.Endpoints.Elements()) > 0
+}
+
+func serviceEndpoints(ctx context.Context, data *ServiceResourceModel) ([]ServiceEndpointModel, diag.Diagnostics) {
+	endpoints := make([]ServiceEndpointModel, 0, len(data.Endpoints.Elements()))
+	if data.Endpoints.IsNull() || data.Endpoints.IsUnknown() {
+		return endpoints, nil
+	}
+	diags := data.Endpoints.ElementsAs(ctx, &endpoints, false)
+	return endpoints, diags
+}
+
+func setServiceEndpoints(ctx context.Context, data *ServiceResourceModel, endpoints []ServiceEndpointModel) diag.Diagnostics {
+	list, diags := types.ListValueFrom(ctx, serviceEndpointElementType, endpoints)
+	if diags.HasError() {
+		return diags
+	}
+	data.Endpoints = list
+	return diags
+}
+
+// clearFlatEndpointState empties the flat endpoint_* attributes, which are not
+// tracked when the endpoints are managed through endpoint blocks.
+func clearFlatEndpointState(data *ServiceResourceModel) {
+	data.Mechanism = types.StringNull()
+	data.AllowedAccounts = types.ListNull(types.StringType)
+	data.AllowList = types.ListNull(allowListElementType)
+	data.ExpiredAllowList = noExpiredAllowListEntries(data.ExpiredAllowList)
+	data.EndpointService = types.StringNull()
+}
+
+// setEndpointsState copies the endpoints returned by the API into the model,
+// either into the endpoint blocks or into the flat attributes for the first one.
+func (r *ServiceResource) setEndpointsState(ctx context.Context, data *ServiceResourceModel, endpoints []provisioning.Endpoint) diag.Diagnostics {
+	if hasEndpointBlocks(data) {
+		return r.readEndpointsState(ctx, data, endpoints)
+	}
+
+	var diags diag.Diagnostics
+	if data.Endpoints.IsNull() || data.Endpoints.IsUnknown() {
+		diags.Append(setServiceEndpoints(ctx, data, []ServiceEndpointModel{})...)
+	}
+	if len(endpoints) > 0 {
+		data.Mechanism = types.StringValue(endpoints[0].Mechanism)
+		r.setAllowAccounts(ctx, data, endpoints[0].AllowedAccounts)
+		diags.Append(r.setAllowListState(ctx, data, endpoints[0].AllowList)...)
+		data.EndpointService = types.StringValue(endpoints[0].EndpointService)
+	} else {
+		data.ExpiredAllowList = noExpiredAllowListEntries(data.ExpiredAllowList)
+	}
+	return diags
+}
+
+// readEndpointsState refreshes the declared endpoints by name. An endpoint that
+// no longer exists is dropped from the state, so the next plan adds it back.
+func (r *ServiceResource) readEndpointsState(ctx context.Context, data *ServiceResourceModel, endpoints []provisioning.Endpoint) diag.Diagnostics {
+	declared, diags := serviceEndpoints(ctx, data)
+	if diags.HasError() {
+		return diags
+	}
+
+	byName := make(map[string]provisioning.Endpoint, len(endpoints))
+	for _, endpoint := range endpoints {
+		byName[endpoint.Name] = endpoint
+	}
+
+	refreshed := make([]ServiceEndpointModel, 0, len(declared))
+	for _, endpoint := range declared {
+		remote, ok := byName[endpoint.Name.ValueString()]
+		if !ok {
+			tflog.Warn(ctx, "SkySQL service endpoint not found, removing from state", map[string]interface{}{
+				"id":       data.ID.ValueString(),
+				"endpoint": endpoint.Name.ValueString(),
+			})
+			continue
+		}
+		refreshed = append(refreshed, r.endpointToModel(ctx, remote, endpoint))
+	}
+
+	clearFlatEndpointState(data)
+	diags.Append(setServiceEndpoints(ctx, data, refreshed)...)
+	return diags
+}
+
+// endpointToModel converts an API endpoint, keeping an empty list from the
+// prior value instead of turning it into null.
+func (r *ServiceResource) endpointToModel(ctx context.Context, endpoint provisioning.Endpoint, prior ServiceEndpointModel) ServiceEndpointModel {
+	model := ServiceEndpointModel{
+		Name:            types.StringValue(endpoint.Name),
+		Mechanism:       types.StringValue(endpoint.Mechanism),
+		Visibility:      types.StringValue(endpoint.Visibility),
+		AllowedAccounts: types.ListNull(types.StringType),
+		AllowList:       types.ListNull(allowListElementType),
+		EndpointService: types.StringValue(endpoint.EndpointService),
+	}
+	if len(endpoint.AllowedAccounts) > 0 || isKnownEmptyList(prior.AllowedAccounts) {
+		model.AllowedAccounts, _ = types.ListValueFrom(ctx, types.StringType, append([]string{}, endpoint.AllowedAccounts...))
+	}
+	if len(endpoint.AllowList) > 0 || isKnownEmptyList(prior.AllowList) {
+		model.AllowList, _ = r.allowListToListType(ctx, append([]provisioning.AllowListItem{}, endpoint.AllowList...))
+	}
+	return model
+}
+
+func isKnownEmptyList(list types.List) bool {
+	return !list.IsNull() && !list.IsUnknown() && len(list.Elements()) == 0
+}
+
+// priorEndpoints returns the endpoints in state keyed by name. A state that
+// still uses the flat endpoint_* attributes is treated as a single endpoint
+// named after the first endpoint block, so switching a configuration over to
+// endpoint blocks does not resubmit settings that are already applied.
+func priorEndpoints(ctx context.Context, plan *ServiceResourceModel, state *ServiceResourceModel) map[string]ServiceEndpointModel {
+	prior := make(map[string]ServiceEndpointModel)
+	if hasEndpointBlocks(state) {
+		endpoints, _ := serviceEndpoints(ctx, state)
+		for _, endpoint := range endpoints {
+			prior[endpoint.Name.ValueString()] = endpoint
+		}
+		return prior
+	}
+
+	planned, _ := serviceEndpoints(ctx, plan)
+	if len(planned) == 0 || state.Mechanism.IsNull() || state.Mechanism.IsUnknown() {
+		return prior
+	}
+	visibility := visibilityPublic
+	if Contains[string](privateConnectMechanisms, state.Mechanism.ValueString()) {
+		visibility = visibilityPrivate
+	}
+	prior[planned[0].Name.ValueString()] = ServiceEndpointModel{
+		Name:            planned[0].Name,
+		Mechanism:       state.Mechanism,
+		Visibility:      types.StringValue(visibility),
+		AllowedAccounts: state.AllowedAccounts,
+		AllowList:       state.AllowList,
+		EndpointService: state.EndpointService,
+	}
+	return prior
+}
+
+// upgradeEndpointState upgrades a state written before the endpoint blocks.
+// The primary endpoint stays in the flat endpoint_* attributes, which match a
+// configuration that still uses them. A configuration that moves to endpoint
+// blocks is matched against them by priorEndpoints.
+func upgradeEndpointState(ctx context.Context, data *ServiceResourceModel) diag.Diagnostics {
+	if !data.Endpoints.IsNull() && !data.Endpoints.IsUnknown() {
+		return nil
+	}
+	return setServiceEndpoints(ctx, data, []ServiceEndpointModel{})
+}
+
+func serviceEndpointBlocksChanged(ctx context.Context, plan *ServiceResourceModel, state *ServiceResourceModel) bool {
+	planned, diags := serviceEndpoints(ctx, plan)
+	if diags.HasError() {
+		// Let the update step report the conversion error.
+		return true
+	}
+	prior := priorEndpoints(ctx, plan, state)
+	for _, endpoint := range planned {
+		current, ok := prior[endpoint.Name.ValueString()]
+		if !ok || serviceEndpointChanged(ctx, endpoint, current) {
+			return true
+		}
+	}
+	return false
+}
+
+func serviceEndpointChanged(ctx context.Context, planned ServiceEndpointModel, prior ServiceEndpointModel) bool {
+	if !planned.Mechanism.IsUnknown() && planned.Mechanism.ValueString() != prior.Mechanism.ValueString() {
+		return true
+	}
+	if !planned.Visibility.IsUnknown() && planned.Visibility.ValueString() != prior.Visibility.ValueString() {
+		return true
+	}
+	if !planned.AllowedAccounts.IsUnknown() && !listElementsEqual[string](ctx, planned.AllowedAccounts, prior.AllowedAccounts) {
+		return true
+	}
+	return !planned.AllowList.IsUnknown() && !listElementsEqual[AllowListModel](ctx, planned.AllowList, prior.AllowList)
+}
+
+// listElementsEqual compares two lists by their elements, a null list is
+// equal to an empty one.
+func listElementsEqual[T any](ctx context.Context, a types.List, b types.List) bool {
+	var aElements []T
+	var bElements []T
+	if a.ElementsAs(ctx, &aElements, false).HasError() || b.ElementsAs(ctx, &bElements, false).HasError() {
+		return false
+	}
+	if len(aElements) == 0 && len(bElements) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(aElements, bElements)
+}
+
+// serviceEndpointRequest builds the API request for a planned endpoint. Values
+// that are not set in the configuration are taken from the prior state.
+func serviceEndpointRequest(ctx context.Context, planned ServiceEndpointModel, prior ServiceEndpointModel) (provisioning.ServiceEndpoint, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	mechanism := planned.Mechanism
+	if mechanism.IsUnknown() {
+		mechanism = prior.Mechanism
+	}
+	request := provisioning.ServiceEndpoint{
+		Name:      planned.Name.ValueString(),
+		Mechanism: mechanism.ValueString(),
+	}
+
+	privateConnect := Contains[string](privateConnectMechanisms, mechanism.ValueString())
+	switch {
+	case !planned.Visibility.IsUnknown() && !planned.Visibility.IsNull():
+		request.Visibility = planned.Visibility.ValueString()
+	case privateConnect:
+		request.Visibility = visibilityPrivate
+	default:
+		request.Visibility = visibilityPublic
+	}
+
+	allowedAccounts := planned.AllowedAccounts
+	if allowedAccounts.IsUnknown() {
+		allowedAccounts = prior.AllowedAccounts
+	}
+	request.AllowedAccounts = []string{}
+	if privateConnect {
+		diags.Append(allowedAccounts.ElementsAs(ctx, &request.AllowedAccounts, false)...)
+		if request.AllowedAccounts == nil {
+			request.AllowedAccounts = []string{}
+		}
+	}
+
+	// An allow list that is not configured is left as it is.
+	if !planned.AllowList.IsUnknown() {
+		var allowList []AllowListModel
+		diags.Append(planned.AllowList.ElementsAs(ctx, &allowList, false)...)
+		items := make([]provisioning.AllowListItem, 0, len(allowList))
+		for _, item := range allowList {
+			items = append(items, provisioning.AllowListItem{
+				IPAddress: item.IPAddress.ValueString(),
+				Comment:   item.Comment.ValueString(),
+			})
+		}
+		request.AllowList = &items
+	}
+
+	return request, diags
+}
+
+// patchServiceEndpoints submits all planned endpoints in a single request and
+// returns them as they were applied.
+func (r *ServiceResource) patchServiceEndpoints(
+	ctx context.Context,
+	serviceID string,
+	planned []ServiceEndpointModel,
+	prior map[string]ServiceEndpointModel,
+) ([]ServiceEndpointModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	request := make(provisioning.PatchServiceEndpointsRequest, 0, len(planned))
+	for _, endpoint := range planned {
+		endpointRequest, d := serviceEndpointRequest(ctx, endpoint, prior[endpoint.Name.ValueString()])
+		diags.Append(d...)
+		request = append(request, endpointRequest)
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	tflog.Info(ctx, "Updating service endpoints", map[string]interface{}{
+		"id":        serviceID,
+		"endpoints": len(request),
+	})
+
+	response, err := r.client.PatchServiceEndpoints(ctx, serviceID, request)
+	if err != nil {
+		diags.AddError("Can not update service endpoints", err.Error())
+		return nil, diags
+	}
+
+	patched := make(map[string]provisioning.ServiceEndpoint, len(response))
+	for _, endpoint := range response {
+		patched[endpoint.Name] = endpoint
+	}
+
+	applied := make([]ServiceEndpointModel, 0, len(planned))
+	for i, endpoint := range planned {
+		result, ok := patched[endpoint.Name.ValueString()]
+		if !ok {
+			// The API did not echo this endpoint back, record what was requested.
+			result = request[i]
+			result.EndpointService = prior[endpoint.Name.ValueString()].EndpointService.ValueString()
+		}
+		remote := provisioning.Endpoint{
+			Name:            endpoint.Name.ValueString(),
+			Mechanism:       result.Mechanism,
+			AllowedAccounts: result.AllowedAccounts,
+			EndpointService: result.EndpointService,
+			Visibility:      result.Visibility,
+		}
+		allowList := result.AllowList
+		if allowList == nil {
+			allowList = request[i].AllowList
+		}
+		if allowList != nil {
+			remote.AllowList = *allowList
+		} else if current, ok := prior[endpoint.Name.ValueString()]; ok && !current.AllowList.IsUnknown() {
+			var allowList []AllowListModel
+			current.AllowList.ElementsAs(ctx, &allowList, false)
+			for _, item := range allowList {
+				remote.AllowList = append(remote.AllowList, provisioning.AllowListItem{
+					IPAddress: item.IPAddress.ValueString(),
+					Comment:   item.Comment.ValueString(),
+				})
+			}
+		}
+		applied = append(applied, r.endpointToModel(ctx, remote, endpoint))
+	}
+	return applied, diags
+}
+
+func (r *ServiceResource) updateServiceEndpointBlocks(ctx context.Context, plan *ServiceResourceModel, state *ServiceResourceModel, resp *resource.UpdateResponse) bool {
+	planned, diags := serviceEndpoints(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return false
+	}
+
+	applied, diags := r.patchServiceEndpoints(ctx, state.ID.ValueString(), planned, priorEndpoints(ctx, plan, state))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return false
+	}
+
+	clearFlatEndpointState(state)
+	resp.Diagnostics.Append(setServiceEndpoints(ctx, state, applied)...)
+	if resp.Diagnostics.HasError() {
+		return false
+	}
+	return r.saveUpdateProgress(ctx, state, resp)
+}
+
+// modifyEndpointsPlan validates the endpoint blocks and carries their computed
+// values over from the prior state, matching endpoints by name.
+func (r *ServiceResource) modifyEndpointsPlan(ctx context.Context, config *ServiceResourceModel, plan *ServiceResourceModel, state *ServiceResourceModel, resp *resource.ModifyPlanResponse) {
+	if !hasEndpointBlocks(plan) {
+		return
+	}
+
+	for _, flat := range []struct {
+		name       string
+		configured bool
+	}{
+		{"endpoint_mechanism", !config.Mechanism.IsNull()},
+		{"endpoint_allowed_accounts", !config.AllowedAccounts.IsNull()},
+		{"allow_list", !config.AllowList.IsNull()},
+	} {
+		if flat.configured {
+			resp.Diagnostics.AddAttributeError(path.Root(flat.name),
+				"Conflicting endpoint configuration",
+				fmt.Sprintf("%s can not be combined with endpoint blocks, configure it inside the endpoint block instead", flat.name))
+		}
+	}
+
+	planned, diags := serviceEndpoints(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	names := make(map[string]bool, len(planned))
+	for i, endpoint := range planned {
+		name := endpoint.Name.ValueString()
+		if names[name] {
+			resp.Diagnostics.AddAttributeError(path.Root("endpoint").AtListIndex(i).AtName("name"),
+				"Duplicate endpoint name",
+				fmt.Sprintf("The endpoint %q is declared more than once", name))
+		}
+		names[name] = true
+
+		if Contains[string](privateConnectMechanisms, endpoint.Mechanism.ValueString()) &&
+			!endpoint.AllowList.IsUnknown() && !endpoint.AllowList.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("endpoint").AtListIndex(i).AtName("allow_list"),
+				fmt.Sprintf("You can not set allow_list when mechanism has %q value", endpoint.Mechanism.ValueString()),
+				fmt.Sprintf("When you set mechanism=%q, don't use allow_list, use allowed_accounts instead", endpoint.Mechanism.ValueString()))
+		}
+
+		var allowList []AllowListModel
+		if !endpoint.AllowList.IsUnknown() && !endpoint.AllowList.ElementsAs(ctx, &allowList, false).HasError() {
+			for j := range allowList {
+				if !allowList[j].ExpiresAt.IsNull() {
+					resp.Diagnostics.AddAttributeError(path.Root("endpoint").AtListIndex(i).AtName("allow_list").AtListIndex(j).AtName("expires_at"),
+						"Invalid configuration",
+						"expires_at is only supported in the allow_list attribute of the service, not in endpoint blocks")
+				}
+			}
+		}
+	}
+
+	if state == nil {
+		if planned[0].Name.ValueString() != primaryEndpointName {
+			resp.Diagnostics.AddAttributeError(path.Root("endpoint").AtListIndex(0).AtName("name"),
+				"Invalid endpoint name",
+				fmt.Sprintf("The first endpoint block describes the endpoint created together with the service and must be named %q", primaryEndpointName))
+		}
+		if len(planned) > 1 && !plan.WaitForCreation.ValueBool() {
+			resp.Diagnostics.AddAttributeError(path.Root("endpoint"),
+				"Invalid configuration",
+				"Declaring more than one endpoint requires wait_for_creation = true. The service must be ready before additional endpoints can be added.")
+		}
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state != nil {
+		prior := priorEndpoints(ctx, plan, state)
+		for i := range planned {
+			endpoint := &planned[i]
+			current, ok := prior[endpoint.Name.ValueString()]
+			if !ok {
+				continue
+			}
+			if endpoint.Mechanism.IsUnknown() {
+				endpoint.Mechanism = current.Mechanism
+			}
+			mechanismChanged := endpoint.Mechanism.ValueString() != current.Mechanism.ValueString()
+			if endpoint.AllowList.IsUnknown() {
+				endpoint.AllowList = current.AllowList
+			}
+			if !mechanismChanged {
+				if endpoint.Visibility.IsUnknown() {
+					endpoint.Visibility = current.Visibility
+				}
+				if endpoint.AllowedAccounts.IsUnknown() {
+					endpoint.AllowedAccounts = current.AllowedAccounts
+				}
+				if endpoint.EndpointService.IsUnknown() {
+					endpoint.EndpointService = current.EndpointService
+				}
+			}
+		}
+	}
+	for i := range planned {
+		if planned[i].Mechanism.ValueString() == "nlb" && planned[i].AllowedAccounts.IsUnknown() {
+			planned[i].AllowedAccounts = types.ListNull(types.StringType)
+		}
+	}
+
+	resp.Diagnostics.Append(setServiceEndpoints(ctx, plan, planned)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("endpoint"), plan.Endpoints)...)
+
+	// The flat attributes only describe the primary endpoint when no blocks are used.
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("endpoint_mechanism"), types.StringNull())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("endpoint_allowed_accounts"), types.ListNull(types.StringType))...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("allow_list"), types.ListNull(allowListElementType))...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("endpoint_service"), types.StringNull())...)
+}
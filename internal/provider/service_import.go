@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql/provisioning"
+)
+
+// importServiceState seeds the state of an imported service. Read only
+// refreshes the attributes that are tracked in state already, so everything
+// that is otherwise only known from the configuration is set here: the
+// optional attributes the API reports, all tags, the applied configuration
+// and the defaults of the provider-side flags.
+func importServiceState(ctx context.Context, service *provisioning.Service, state *tfsdk.State) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	diags.Append(state.SetAttribute(ctx, path.Root("id"), service.ID)...)
+	if service.ProjectID != "" {
+		diags.Append(state.SetAttribute(ctx, path.Root("project_id"), service.ProjectID)...)
+	}
+
+	// The same defaults the plan modifiers apply when the flags are not configured.
+	diags.Append(state.SetAttribute(ctx, path.Root("wait_for_creation"), true)...)
+	diags.Append(state.SetAttribute(ctx, path.Root("wait_for_deletion"), true)...)
+	diags.Append(state.SetAttribute(ctx, path.Root("wait_for_update"), true)...)
+	diags.Append(state.SetAttribute(ctx, path.Root("deletion_protection"), true)...)
+	diags.Append(state.SetAttribute(ctx, path.Root("final_backup"), false)...)
+	diags.Append(state.SetAttribute(ctx, path.Root("ignore_allow_list"), false)...)
+
+	// Left null when the API reports the default, so that a configuration
+	// that omits them plans clean.
+	if service.NosqlEnabled {
+		diags.Append(state.SetAttribute(ctx, path.Root("nosql_enabled"), true)...)
+	}
+	if service.ReplicationEnabled {
+		diags.Append(state.SetAttribute(ctx, path.Root("replication_enabled"), true)...)
+	}
+	if service.PrimaryHost != "" {
+		diags.Append(state.SetAttribute(ctx, path.Root("primary_host"), service.PrimaryHost)...)
+	}
+	if service.StorageVolume.IOPS > 0 {
+		diags.Append(state.SetAttribute(ctx, path.Root("volume_iops"), int64(service.StorageVolume.IOPS))...)
+	}
+	if service.StorageVolume.Throughput > 0 {
+		diags.Append(state.SetAttribute(ctx, path.Root("volume_throughput"), int64(service.StorageVolume.Throughput))...)
+	}
+	if service.MaxscaleNodes > 0 {
+		diags.Append(state.SetAttribute(ctx, path.Root("maxscale_nodes"), int64(service.MaxscaleNodes))...)
+	}
+
+	if len(service.Tags) > 0 {
+		tags, d := types.MapValueFrom(ctx, types.StringType, service.Tags)
+		diags.Append(d...)
+		diags.Append(state.SetAttribute(ctx, path.Root("tags"), tags)...)
+	}
+	if service.ConfigID != "" {
+		diags.Append(state.SetAttribute(ctx, path.Root("config_id"), service.ConfigID)...)
+	}
+
+	return diags
+}
@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql/provisioning"
+)
+
+// defaultMaintenanceTimeZone is the time zone of a maintenance window that
+// does not set one.
+const defaultMaintenanceTimeZone = "UTC"
+
+// ServiceMaintenanceWindowModel describes the maintenance_window block of the service.
+type ServiceMaintenanceWindowModel struct {
+	DayOfWeek     types.String `tfsdk:"day_of_week"`
+	StartHour     types.Int64  `tfsdk:"start_hour"`
+	DurationHours types.Int64  `tfsdk:"duration_hours"`
+	TimeZone      types.String `tfsdk:"time_zone"`
+}
+
+var maintenanceWindowAttrTypes = map[string]attr.Type{
+	"day_of_week":    types.StringType,
+	"start_hour":     types.Int64Type,
+	"duration_hours": types.Int64Type,
+	"time_zone":      types.StringType,
+}
+
+var serviceMaintenanceWindowBlock = schema.SingleNestedBlock{
+	Description: "The weekly window SkySQL runs patching and other maintenance of the service in. " +
+		"The window is only tracked while the block is declared, removing the block leaves the window of the service unchanged.",
+	Attributes: map[string]schema.Attribute{
+		"day_of_week": schema.StringAttribute{
+			Required:    true,
+			Description: "The day the window starts on. Valid values are: monday, tuesday, wednesday, thursday, friday, saturday or sunday",
+			Validators: []validator.String{
+				stringvalidator.OneOf("monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"),
+			},
+		},
+		"start_hour": schema.Int64Attribute{
+			Required:    true,
+			Description: "The hour the window starts at, from 0 to 23",
+			Validators: []validator.Int64{
+				int64validator.Between(0, 23),
+			},
+		},
+		"duration_hours": schema.Int64Attribute{
+			Required:    true,
+			Description: "The length of the window in hours, from 1 to 24",
+			Validators: []validator.Int64{
+				int64validator.Between(1, 24),
+			},
+		},
+		"time_zone": schema.StringAttribute{
+			Optional:    true,
+			Description: "The IANA time zone of start_hour, e.g. Europe/Berlin. Default is UTC",
+			Validators: []validator.String{
+				timeZoneValidator{},
+			},
+		},
+	},
+}
+
+type timeZoneValidator struct{}
+
+// Description returns a plain text description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (v timeZoneValidator) Description(ctx context.Context) string {
+	return "time zone must be an IANA time zone name, e.g. UTC or Europe/Berlin"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (v timeZoneValidator) MarkdownDescription(ctx context.Context) string {
+	return "time zone must be an IANA time zone name, e.g. `UTC` or `Europe/Berlin`"
+}
+
+// ValidateString Validate runs the main validation logic of the validator, reading configuration data out of `req` and updating `resp` with diagnostics.
+func (v timeZoneValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	if _, err := time.LoadLocation(req.ConfigValue.ValueString()); err != nil || req.ConfigValue.ValueString() == "" {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid time zone",
+			fmt.Sprintf("%q is not an IANA time zone name, e.g. UTC or Europe/Berlin.", req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// serviceMaintenanceWindow converts the maintenance_window block to its API
+// representation, nil when the block is not declared.
+func serviceMaintenanceWindow(ctx context.Context, data *ServiceResourceModel) (*provisioning.MaintenanceWindow, diag.Diagnostics) {
+	if data.MaintenanceWindow.IsNull() || data.MaintenanceWindow.IsUnknown() {
+		return nil, nil
+	}
+	var window ServiceMaintenanceWindowModel
+	diags := data.MaintenanceWindow.As(ctx, &window, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return nil, diags
+	}
+	timeZone := window.TimeZone.ValueString()
+	if timeZone == "" {
+		timeZone = defaultMaintenanceTimeZone
+	}
+	return &provisioning.MaintenanceWindow{
+		DayOfWeek:     window.DayOfWeek.ValueString(),
+		StartHour:     window.StartHour.ValueInt64(),
+		DurationHours: window.DurationHours.ValueInt64(),
+		TimeZone:      timeZone,
+	}, diags
+}
+
+// setMaintenanceWindowState reads the maintenance window of the service back
+// into the model. The window is only tracked while the block is declared, a
+// null time zone stays null as long as the service uses the default.
+func setMaintenanceWindowState(ctx context.Context, data *ServiceResourceModel, service *provisioning.Service) diag.Diagnostics {
+	if data.MaintenanceWindow.IsNull() || data.MaintenanceWindow.IsUnknown() {
+		return nil
+	}
+	var prior ServiceMaintenanceWindowModel
+	diags := data.MaintenanceWindow.As(ctx, &prior, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return diags
+	}
+
+	window := service.MaintenanceWindow
+	if window == nil {
+		// Removed outside of Terraform, the next apply sets it again.
+		data.MaintenanceWindow = types.ObjectNull(maintenanceWindowAttrTypes)
+		return diags
+	}
+
+	timeZone := types.StringValue(window.TimeZone)
+	if prior.TimeZone.IsNull() && (window.TimeZone == "" || window.TimeZone == defaultMaintenanceTimeZone) {
+		timeZone = types.StringNull()
+	}
+
+	var d diag.Diagnostics
+	data.MaintenanceWindow, d = types.ObjectValueFrom(ctx, maintenanceWindowAttrTypes, ServiceMaintenanceWindowModel{
+		DayOfWeek:     types.StringValue(window.DayOfWeek),
+		StartHour:     types.Int64Value(window.StartHour),
+		DurationHours: types.Int64Value(window.DurationHours),
+		TimeZone:      timeZone,
+	})
+	diags.Append(d...)
+	return diags
+}
+
+func serviceMaintenanceWindowChanged(_ context.Context, plan *ServiceResourceModel, state *ServiceResourceModel) bool {
+	return !plan.MaintenanceWindow.IsUnknown() && !plan.MaintenanceWindow.Equal(state.MaintenanceWindow)
+}
+
+func (r *ServiceResource) updateMaintenanceWindow(ctx context.Context, plan *ServiceResourceModel, state *ServiceResourceModel, resp *resource.UpdateResponse) bool {
+	if !serviceMaintenanceWindowChanged(ctx, plan, state) {
+		return false
+	}
+
+	// Removing the block only stops managing the window.
+	if plan.MaintenanceWindow.IsNull() {
+		state.MaintenanceWindow = plan.MaintenanceWindow
+		r.saveUpdateProgress(ctx, state, resp)
+		return false
+	}
+
+	window, diags := serviceMaintenanceWindow(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return false
+	}
+
+	tflog.Info(ctx, "Updating service maintenance window", map[string]interface{}{
+		"id":          state.ID.ValueString(),
+		"day_of_week": window.DayOfWeek,
+		"start_hour":  window.StartHour,
+	})
+	err := r.client.SetServiceMaintenanceWindow(ctx, state.ID.ValueString(), window)
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating maintenance window", fmt.Sprintf("Unable to update maintenance_window, got error: %s", err))
+		return false
+	}
+	state.MaintenanceWindow = plan.MaintenanceWindow
+	return r.saveUpdateProgress(ctx, state, resp)
+}
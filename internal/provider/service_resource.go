@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"reflect"
 	"regexp"
 	"time"
 
@@ -20,6 +19,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -27,6 +28,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	sdkresource "github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql/backup"
 	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql/provisioning"
 )
 
@@ -47,8 +49,9 @@ var _ resource.ResourceWithUpgradeState = &ServiceResource{}
 
 var allowListElementType = types.ObjectType{
 	AttrTypes: map[string]attr.Type{
-		"ip":      types.StringType,
-		"comment": types.StringType,
+		"ip":         CIDRType{},
+		"comment":    types.StringType,
+		"expires_at": types.StringType,
 	},
 }
 
@@ -100,6 +103,18 @@ type ServiceResourceModel struct {
 	AvailabilityZone   types.String   `tfsdk:"availability_zone"`
 	Tags               types.Map      `tfsdk:"tags"`
 	ConfigID           types.String   `tfsdk:"config_id"`
+	Endpoints          types.List     `tfsdk:"endpoint"`
+	Ports              types.List     `tfsdk:"ports"`
+	OutboundIps        types.List     `tfsdk:"outbound_ips"`
+	ReadWritePort      types.Int64    `tfsdk:"readwrite_port"`
+	ReadOnlyPort       types.Int64    `tfsdk:"readonly_port"`
+	ConnectionURIs     types.Map      `tfsdk:"connection_uris"`
+	FinalBackup        types.Bool     `tfsdk:"final_backup"`
+	FinalBackupName    types.String   `tfsdk:"final_backup_name"`
+	RestoreFrom        types.Object   `tfsdk:"restore_from"`
+	MaintenanceWindow  types.Object   `tfsdk:"maintenance_window"`
+	ExpiredAllowList   types.List     `tfsdk:"expired_allow_list_entries"`
+	IgnoreAllowList    types.Bool     `tfsdk:"ignore_allow_list"`
 }
 
 // serviceResourceModelV1 is the model for schema version 1 (includes org_id that was removed in v2).
@@ -142,26 +157,80 @@ type serviceResourceModelV1 struct {
 	ConfigID           types.String   `tfsdk:"config_id"`
 }
 
+// serviceResourceAddedSinceV1 lists the attributes and blocks that were added
+// to the schema after version 1, they are not part of the version 1 state.
+var serviceResourceAddedSinceV1 = map[string]bool{
+	"endpoint":           true,
+	"ports":              true,
+	"outbound_ips":       true,
+	"readwrite_port":     true,
+	"readonly_port":      true,
+	"connection_uris":    true,
+	"final_backup":       true,
+	"final_backup_name":  true,
+	"restore_from":       true,
+	"maintenance_window": true,
+
+	"expired_allow_list_entries": true,
+	"ignore_allow_list":          true,
+}
+
 // serviceResourcePriorSchemaV1 returns the schema for version 1 (with org_id).
 func serviceResourcePriorSchemaV1() *schema.Schema {
 	attrs := make(map[string]schema.Attribute, len(serviceResourceSchemaV0.Attributes)+1)
 	for k, v := range serviceResourceSchemaV0.Attributes {
-		attrs[k] = v
+		if !serviceResourceAddedSinceV1[k] {
+			attrs[k] = v
+		}
 	}
 	attrs["org_id"] = schema.StringAttribute{
 		Optional:    true,
 		Description: "Deprecated: Organization ID (moved to provider level)",
 	}
+	blocks := make(map[string]schema.Block, len(serviceResourceSchemaV0.Blocks))
+	for k, v := range serviceResourceSchemaV0.Blocks {
+		if !serviceResourceAddedSinceV1[k] {
+			blocks[k] = v
+		}
+	}
 	return &schema.Schema{
 		Attributes: attrs,
-		Blocks:     serviceResourceSchemaV0.Blocks,
+		Blocks:     blocks,
 	}
 }
 
 // ServiceResourceNamedPortModel is an endpoint port
 type ServiceResourceNamedPortModel struct {
-	Name types.String `tfsdk:"name"`
-	Port types.Int64  `tfsdk:"port"`
+	Name    types.String `tfsdk:"name"`
+	Port    types.Int64  `tfsdk:"port"`
+	Purpose types.String `tfsdk:"purpose"`
+}
+
+// serviceAllowListNestedObject is an allow list entry of the flat allow_list
+// attribute and of the endpoint blocks.
+var serviceAllowListNestedObject = schema.NestedAttributeObject{
+	Attributes: map[string]schema.Attribute{
+		"ip": schema.StringAttribute{
+			Required:    true,
+			CustomType:  CIDRType{},
+			Description: "The IP address to allow access to the service. The IP must be an IPv4 or IPv6 address or a range in CIDR format",
+			Validators: []validator.String{
+				allowListIPValidator{},
+			},
+		},
+		"comment": schema.StringAttribute{
+			Optional:    true,
+			Description: "A comment to describe the IP address",
+		},
+		"expires_at": schema.StringAttribute{
+			Optional: true,
+			Description: "The time the entry expires at in RFC 3339 format, e.g. 2026-11-01T00:00:00Z. Expired entries are removed " +
+				"from the service on the next apply. The expiry is kept in the comment of the entry. Not supported in endpoint blocks",
+			Validators: []validator.String{
+				rfc3339Validator{},
+			},
+		},
+	},
 }
 
 func (r *ServiceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -170,7 +239,7 @@ func (r *ServiceResource) Metadata(ctx context.Context, req resource.MetadataReq
 
 var serviceResourceSchemaV0 = schema.Schema{
 	Description: "Creates and manages a service in SkySQL",
-	Version:     2,
+	Version:     3,
 	Attributes: map[string]schema.Attribute{
 		"id": schema.StringAttribute{
 			Required: false,
@@ -195,8 +264,10 @@ var serviceResourceSchemaV0 = schema.Schema{
 		"project_id": schema.StringAttribute{
 			Required:    false,
 			Optional:    true,
+			Computed:    true,
 			Description: "The ID of the project to create the service in",
 			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
 				stringplanmodifier.RequiresReplace(),
 			},
 		},
@@ -287,9 +358,8 @@ var serviceResourceSchemaV0 = schema.Schema{
 		"ssl_enabled": schema.BoolAttribute{
 			Optional:    true,
 			Computed:    true,
-			Description: "Whether to enable SSL. Valid values are: true or false",
+			Description: "Whether to enable SSL. Valid values are: true or false. Can be changed in-place, clients have to reconnect with the matching TLS setting",
 			PlanModifiers: []planmodifier.Bool{
-				boolplanmodifier.RequiresReplace(),
 				boolplanmodifier.UseStateForUnknown(),
 			},
 		},
@@ -297,7 +367,7 @@ var serviceResourceSchemaV0 = schema.Schema{
 			Optional:    true,
 			Description: "Whether to enable NoSQL. Valid values are: true or false",
 			PlanModifiers: []planmodifier.Bool{
-				boolplanmodifier.RequiresReplace(),
+				boolRequiresReplaceIfValueChanged(),
 				boolplanmodifier.UseStateForUnknown(),
 			},
 		},
@@ -355,7 +425,7 @@ var serviceResourceSchemaV0 = schema.Schema{
 			Optional:    true,
 			Description: "Whether to enable global replication. Valid values are: true or false. Works for xpand-direct topology only",
 			PlanModifiers: []planmodifier.Bool{
-				boolplanmodifier.RequiresReplace(),
+				boolRequiresReplaceIfValueChanged(),
 				boolplanmodifier.UseStateForUnknown(),
 			},
 		},
@@ -363,7 +433,7 @@ var serviceResourceSchemaV0 = schema.Schema{
 			Optional:    true,
 			Description: "The primary host of the service",
 			PlanModifiers: []planmodifier.String{
-				stringplanmodifier.RequiresReplace(),
+				stringRequiresReplaceIfValueChanged(),
 				stringplanmodifier.UseStateForUnknown(),
 			},
 		},
@@ -393,43 +463,87 @@ var serviceResourceSchemaV0 = schema.Schema{
 				boolplanmodifier.UseStateForUnknown(),
 			},
 		},
-		"allow_list": schema.ListNestedAttribute{
-			Required:    false,
+		"final_backup": schema.BoolAttribute{
+			Optional:    true,
 			Computed:    true,
+			Description: "Whether to take a full backup of the service before it is deleted. The deletion only proceeds once the backup has completed. Like deletion_protection, the value must be applied before the service is destroyed. Default is false",
+			PlanModifiers: []planmodifier.Bool{
+				boolDefault(false),
+				boolplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"restore_from": schema.SingleNestedAttribute{
 			Optional:    true,
-			Description: "The list of IP addresses with comments to allow access to the service",
-			NestedObject: schema.NestedAttributeObject{
-				Attributes: map[string]schema.Attribute{
-					"ip": schema.StringAttribute{
-						Required:    true,
-						Description: "The IP address to allow access to the service. The IP must be in a valid CIDR format",
-						Validators: []validator.String{
-							allowListIPValidator{},
-						},
-					},
-					"comment": schema.StringAttribute{
-						Optional:    true,
-						Description: "A comment to describe the IP address",
+			Description: "Creates the service from a backup, or from another service at a point in time. Set either backup_id or source_service_id with point_in_time. Requires wait_for_creation = true. Changing the source replaces the service",
+			PlanModifiers: []planmodifier.Object{
+				objectplanmodifier.RequiresReplace(),
+			},
+			Attributes: map[string]schema.Attribute{
+				"backup_id": schema.StringAttribute{
+					Optional:    true,
+					Description: "The ID of the backup to restore",
+				},
+				"source_service_id": schema.StringAttribute{
+					Optional:    true,
+					Description: "The ID of the service to restore from at point_in_time",
+				},
+				"point_in_time": schema.StringAttribute{
+					Optional:    true,
+					Description: "The time to restore source_service_id to, in RFC 3339 format. It must be within the point-in-time window of the backups of the source service",
+				},
+				"method": schema.StringAttribute{
+					Optional:    true,
+					Description: "How the service is restored. Valid values are: create or restore. With create (the default) the source is sent with the create request, with restore an empty service is provisioned and the source is restored into it once the service is ready",
+					Validators: []validator.String{
+						stringvalidator.OneOf(restoreMethodCreate, restoreMethodRestore),
 					},
 				},
 			},
+		},
+		"final_backup_name": schema.StringAttribute{
+			Optional:    true,
+			Description: "The name of the final backup. Only used with final_backup = true, a name is generated by SkySQL otherwise",
+		},
+		"allow_list": schema.ListNestedAttribute{
+			Required:     false,
+			Computed:     true,
+			Optional:     true,
+			Description:  "The list of IP addresses with comments to allow access to the service",
+			NestedObject: serviceAllowListNestedObject,
+			Validators: []validator.List{
+				allowListOverlapValidator{},
+			},
 			PlanModifiers: []planmodifier.List{
 				listplanmodifier.UseStateForUnknown(),
 			},
 		},
+		"expired_allow_list_entries": schema.ListNestedAttribute{
+			Computed:     true,
+			Description:  "The entries of allow_list that have expired and are removed from the service",
+			NestedObject: allowListComputedNestedObject,
+		},
+		"ignore_allow_list": schema.BoolAttribute{
+			Optional: true,
+			Computed: true,
+			Description: "Whether the allow list of the service is neither set nor tracked by this resource. Set it when the allow list is managed " +
+				"by skysql_allow_list, and do not set allow_list. Default is false",
+			PlanModifiers: []planmodifier.Bool{
+				boolDefault(false),
+			},
+		},
 		"maxscale_nodes": schema.Int64Attribute{
 			Optional:    true,
-			Description: "The number of MaxScale nodes",
+			Computed:    true,
+			Description: "The number of MaxScale nodes. Can be changed in-place",
 			PlanModifiers: []planmodifier.Int64{
-				int64planmodifier.RequiresReplace(),
 				int64planmodifier.UseStateForUnknown(),
 			},
 		},
 		"maxscale_size": schema.StringAttribute{
 			Optional:    true,
-			Description: "The size of the MaxScale nodes. Valid values are: sky-2x4, sky-2x8 etc",
+			Computed:    true,
+			Description: "The size of the MaxScale nodes. Valid values are: sky-2x4, sky-2x8 etc. Can be changed in-place",
 			PlanModifiers: []planmodifier.String{
-				stringplanmodifier.RequiresReplace(),
 				stringplanmodifier.UseStateForUnknown(),
 			},
 		},
@@ -442,6 +556,59 @@ var serviceResourceSchemaV0 = schema.Schema{
 			},
 			Description: "The fully qualified domain name of the service. The FQDN is only available when the service is in the ready state",
 		},
+		"ports": schema.ListNestedAttribute{
+			Computed:    true,
+			Description: "The ports of the primary endpoint of the service",
+			PlanModifiers: []planmodifier.List{
+				listplanmodifier.UseStateForUnknown(),
+			},
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Computed:    true,
+						Description: "The name of the port",
+					},
+					"port": schema.Int64Attribute{
+						Computed:    true,
+						Description: "The port number",
+					},
+					"purpose": schema.StringAttribute{
+						Computed:    true,
+						Description: "The purpose of the port, e.g. readwrite, readonly or nosql",
+					},
+				},
+			},
+		},
+		"outbound_ips": schema.ListAttribute{
+			Computed:    true,
+			ElementType: types.StringType,
+			Description: "The outbound IP addresses of the service",
+			PlanModifiers: []planmodifier.List{
+				listplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"readwrite_port": schema.Int64Attribute{
+			Computed:    true,
+			Description: "The read-write port of the primary endpoint",
+			PlanModifiers: []planmodifier.Int64{
+				int64planmodifier.UseStateForUnknown(),
+			},
+		},
+		"readonly_port": schema.Int64Attribute{
+			Computed:    true,
+			Description: "The read-only port of the primary endpoint. Only available for topologies with a read-only listener",
+			PlanModifiers: []planmodifier.Int64{
+				int64planmodifier.UseStateForUnknown(),
+			},
+		},
+		"connection_uris": schema.MapAttribute{
+			Computed:    true,
+			ElementType: types.StringType,
+			Description: "Connection strings without credentials built from the FQDN, the ports and `ssl_enabled`. Keys are mysql, jdbc and odbc, with a `_readonly` suffix for the read-only port",
+			PlanModifiers: []planmodifier.Map{
+				mapplanmodifier.UseStateForUnknown(),
+			},
+		},
 		"endpoint_service": schema.StringAttribute{
 			Required:    false,
 			Optional:    false,
@@ -475,10 +642,14 @@ var serviceResourceSchemaV0 = schema.Schema{
 				"**Update behavior:**\n" +
 				"- **Set or change** `config_id` → applies the new configuration to the service via `POST /services/{id}/config`.\n" +
 				"- **Remove** `config_id` → reverts the service to its default configuration via `DELETE /services/{id}/config`.\n" +
-				"- If the service already has the specified config applied (e.g. after import), the operation is a no-op.",
+				"- If the service already has the specified config applied (e.g. after import), the operation is a no-op.\n" +
+				"- A configuration attached, swapped or detached outside of Terraform is detected on refresh and the plan shows the change needed to converge.\n" +
+				"- If the configuration in state was deleted outside of Terraform, removing `config_id` only clears it from state.",
 		},
 	},
 	Blocks: map[string]schema.Block{
+		"endpoint":           serviceEndpointBlock,
+		"maintenance_window": serviceMaintenanceWindowBlock,
 		"timeouts": timeouts.Block(context.Background(), timeouts.Opts{
 			Create: true,
 			Delete: true,
@@ -530,6 +701,12 @@ func (r *ServiceResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	restoreFrom, diags := serviceRestoreFrom(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	createServiceRequest := &provisioning.CreateServiceRequest{
 		Name:               state.Name.ValueString(),
 		ProjectID:          state.ProjectID.ValueString(),
@@ -552,6 +729,13 @@ func (r *ServiceResource) Create(ctx context.Context, req resource.CreateRequest
 		PrimaryHost:        state.PrimaryHost.ValueString(),
 		MaxscaleNodes:      uint(state.MaxscaleNodes.ValueInt64()),
 		AvailabilityZone:   state.AvailabilityZone.ValueString(),
+		RestoreFrom:        restoreSource(restoreFrom),
+	}
+
+	createServiceRequest.MaintenanceWindow, diags = serviceMaintenanceWindow(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// Convert Tags from Terraform to map[string]string
@@ -591,12 +775,14 @@ func (r *ServiceResource) Create(ctx context.Context, req resource.CreateRequest
 			resp.Diagnostics.Append(diags...)
 			return
 		}
+		expired, diags := plannedExpiredAllowListEntries(ctx, state.ExpiredAllowList, allowList)
+		if diags.HasError() {
+			resp.Diagnostics.Append(diags...)
+			return
+		}
 
-		for _, allowListItem := range allowList {
-			createServiceRequest.AllowList = append(createServiceRequest.AllowList, provisioning.AllowListItem{
-				IPAddress: allowListItem.IPAddress.ValueString(),
-				Comment:   allowListItem.Comment.ValueString(),
-			})
+		for _, allowListItem := range activeAllowListEntries(allowList, expired) {
+			createServiceRequest.AllowList = append(createServiceRequest.AllowList, allowListItemFromModel(allowListItem))
 		}
 	}
 
@@ -608,16 +794,55 @@ func (r *ServiceResource) Create(ctx context.Context, req resource.CreateRequest
 		}
 	}
 
+	// The first endpoint block describes the endpoint created with the service,
+	// the others are added once the service is ready.
+	endpoints, diags := serviceEndpoints(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(endpoints) > 0 {
+		primary, diags := serviceEndpointRequest(ctx, endpoints[0], ServiceEndpointModel{})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		createServiceRequest.Mechanism = primary.Mechanism
+		if Contains[string](privateConnectMechanisms, primary.Mechanism) {
+			createServiceRequest.AllowedAccounts = primary.AllowedAccounts
+		}
+		if primary.AllowList != nil {
+			createServiceRequest.AllowList = *primary.AllowList
+		}
+	}
+
 	service, err := r.client.CreateService(ctx, createServiceRequest)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating service", err.Error())
 		return
 	}
 
+	// The ID of a new service is only known now, record that it owns its
+	// allow list.
+	var configAllowList types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("allow_list"), &configAllowList)...)
+	if !configAllowList.IsNull() && !state.IgnoreAllowList.ValueBool() &&
+		Contains[string](claimAllowList(service.ID, allowListOwnerService), allowListOwnerAllowList) {
+		summary, detail := allowListOwnerConflict(service.ID)
+		resp.Diagnostics.AddAttributeWarning(path.Root("allow_list"), summary, detail)
+	}
+
 	// save into the Terraform state.
 	state.ID = types.StringValue(service.ID)
 	state.Name = types.StringValue(service.Name)
 	state.FQDN = types.StringValue(service.FQDN)
+	if state.ProjectID.IsUnknown() {
+		// Not set in config, track the project the API has chosen.
+		state.ProjectID = types.StringNull()
+		if service.ProjectID != "" {
+			state.ProjectID = types.StringValue(service.ProjectID)
+		}
+	}
 
 	tflog.Trace(ctx, "created a resource")
 
@@ -645,16 +870,21 @@ func (r *ServiceResource) Create(ctx context.Context, req resource.CreateRequest
 	state.Storage = types.Int64Value(int64(service.StorageVolume.Size))
 	state.SSLEnabled = types.BoolValue(service.SSLEnabled)
 	state.AvailabilityZone = types.StringValue(service.AvailabilityZone)
-	if len(service.Endpoints) > 0 {
-		state.Mechanism = types.StringValue(service.Endpoints[0].Mechanism)
-		r.setAllowAccounts(ctx, state, service.Endpoints[0].AllowedAccounts)
-		state.AllowList, _ = r.allowListToListType(ctx, service.Endpoints[0].AllowList)
-		state.EndpointService = types.StringValue(service.Endpoints[0].EndpointService)
+	resp.Diagnostics.Append(r.setEndpointsState(ctx, state, service.Endpoints)...)
+	resp.Diagnostics.Append(setConnectionState(ctx, state, service)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if !(state.MaxscaleSize.IsUnknown() || state.MaxscaleSize.IsNull()) && service.MaxscaleSize != nil {
+	if state.MaxscaleSize.IsUnknown() {
+		// Not set in config, track whatever the API has chosen.
+		state.MaxscaleSize = types.StringNull()
+		if service.MaxscaleSize != nil && len(*service.MaxscaleSize) > 0 {
+			state.MaxscaleSize = types.StringValue(*service.MaxscaleSize)
+		}
+	} else if !state.MaxscaleSize.IsNull() && service.MaxscaleSize != nil {
 		state.MaxscaleSize = types.StringValue(*service.MaxscaleSize)
 	}
-	if !(state.MaxscaleNodes.IsUnknown() || state.MaxscaleNodes.IsNull()) {
+	if !state.MaxscaleNodes.IsNull() {
 		state.MaxscaleNodes = types.Int64Value(int64(service.MaxscaleNodes))
 	}
 	// Save state into Terraform state
@@ -698,10 +928,52 @@ func (r *ServiceResource) Create(ctx context.Context, req resource.CreateRequest
 		if resp.Diagnostics.HasError() {
 			return
 		}
+		// Endpoints are matched by name, restore the declared ones before reading them back.
+		state.Endpoints = plan.Endpoints
 		r.readServiceState(ctx, state)
 		r.updateAllowedAccountsState(plan, state)
 		r.updateAllowListState(plan, state)
 
+		// Restore the data before anything else is changed on the service.
+		if restoreFrom != nil {
+			err = r.restoreService(ctx, service.ID, service.RestoreID, restoreFrom, createTimeout)
+			if err != nil {
+				resp.Diagnostics.AddError("Error restoring service",
+					fmt.Sprintf("Unable to restore service %q: %s", service.ID, err))
+				return
+			}
+		}
+
+		// Add the endpoints that are not created together with the service.
+		if len(endpoints) > 1 {
+			applied, diags := r.patchServiceEndpoints(ctx, service.ID, endpoints, priorEndpoints(ctx, plan, state))
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			resp.Diagnostics.Append(setServiceEndpoints(ctx, state, applied)...)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			err = sdkresource.RetryContext(ctx, createTimeout, func() *sdkresource.RetryError {
+				svc, err := r.client.GetServiceByID(ctx, service.ID)
+				if err != nil {
+					return sdkresource.NonRetryableError(fmt.Errorf("error retrieving service details: %v", err))
+				}
+				if Contains[string](serviceUpdateWaitStates, svc.Status) {
+					return nil
+				}
+				return sdkresource.RetryableError(fmt.Errorf("expected instance to be ready but was in state %s", svc.Status))
+			})
+			if err != nil {
+				resp.Diagnostics.AddError("Error updating service endpoints",
+					fmt.Sprintf("Service did not return to ready state after adding endpoints: %s", err))
+				return
+			}
+		}
+
 		// Apply config after service is ready.
 		if !plan.ConfigID.IsNull() && !plan.ConfigID.IsUnknown() && plan.ConfigID.ValueString() != "" {
 			configID := plan.ConfigID.ValueString()
@@ -709,13 +981,17 @@ func (r *ServiceResource) Create(ctx context.Context, req resource.CreateRequest
 				"service_id": service.ID,
 				"config_id":  configID,
 			})
-			err = r.client.ApplyConfigToService(ctx, service.ID, configID)
+			err = r.applyServiceConfig(ctx, service.ID, configID)
 			if err != nil {
 				resp.Diagnostics.AddError("Error applying configuration to service",
 					fmt.Sprintf("Unable to apply config %q to service %q: %s", configID, service.ID, err.Error()))
 				return
 			}
 			state.ConfigID = types.StringValue(configID)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
 
 			// Wait for config apply to complete.
 			err = sdkresource.RetryContext(ctx, createTimeout, func() *sdkresource.RetryError {
@@ -749,10 +1025,7 @@ func (r *ServiceResource) allowListToListType(ctx context.Context, allowList []p
 	}
 	allowListModels := make([]AllowListModel, 0, len(allowList))
 	for _, allowListItem := range allowList {
-		allowListModels = append(allowListModels, AllowListModel{
-			IPAddress: types.StringValue(allowListItem.IPAddress),
-			Comment:   types.StringValue(allowListItem.Comment),
-		})
+		allowListModels = append(allowListModels, allowListModelFromItem(allowListItem))
 	}
 
 	list, diags := types.ListValueFrom(ctx, allowListElementType, allowListModels)
@@ -797,6 +1070,18 @@ func (r *ServiceResource) Read(ctx context.Context, req resource.ReadRequest, re
 	}
 }
 
+// setMaxscaleState tracks the MaxScale nodes and size reported by the API,
+// whether or not they were set in the configuration, so that out-of-band
+// changes show up as drift.
+func setMaxscaleState(data *ServiceResourceModel, service *provisioning.Service) {
+	data.MaxscaleNodes = types.Int64Value(int64(service.MaxscaleNodes))
+	if service.MaxscaleSize != nil && len(*service.MaxscaleSize) > 0 {
+		data.MaxscaleSize = types.StringValue(*service.MaxscaleSize)
+	} else {
+		data.MaxscaleSize = types.StringNull()
+	}
+}
+
 func (r *ServiceResource) readServiceState(ctx context.Context, data *ServiceResourceModel) error {
 	service, err := r.client.GetServiceByID(ctx, data.ID.ValueString())
 	if err != nil {
@@ -805,6 +1090,9 @@ func (r *ServiceResource) readServiceState(ctx context.Context, data *ServiceRes
 	data.ID = types.StringValue(service.ID)
 	data.FQDN = types.StringValue(service.FQDN)
 	data.Name = types.StringValue(service.Name)
+	if service.ProjectID != "" {
+		data.ProjectID = types.StringValue(service.ProjectID)
+	}
 	data.SSLEnabled = types.BoolValue(service.SSLEnabled)
 	data.ServiceType = types.StringValue(service.ServiceType)
 	data.Provider = types.StringValue(service.Provider)
@@ -835,19 +1123,22 @@ func (r *ServiceResource) readServiceState(ctx context.Context, data *ServiceRes
 	}
 	data.IsActive = types.BoolValue(service.IsActive)
 	data.SSLEnabled = types.BoolValue(service.SSLEnabled)
-	if len(service.Endpoints) > 0 {
-		data.Mechanism = types.StringValue(service.Endpoints[0].Mechanism)
-		r.setAllowAccounts(ctx, data, service.Endpoints[0].AllowedAccounts)
-		data.AllowList, _ = r.allowListToListType(ctx, service.Endpoints[0].AllowList)
-		data.EndpointService = types.StringValue(service.Endpoints[0].EndpointService)
+	if diags := r.setEndpointsState(ctx, data, service.Endpoints); diags.HasError() {
+		return fmt.Errorf("can not read service endpoints: %v", diags.Errors())
 	}
-	if !(data.MaxscaleSize.IsUnknown() || data.MaxscaleSize.IsNull()) && service.MaxscaleSize != nil {
-		data.MaxscaleSize = types.StringValue(*service.MaxscaleSize)
-	} else {
-		data.MaxscaleSize = types.StringNull()
+	if diags := setConnectionState(ctx, data, service); diags.HasError() {
+		return fmt.Errorf("can not read service connection details: %v", diags.Errors())
 	}
-	if !(data.MaxscaleNodes.IsUnknown() || data.MaxscaleSize.IsNull()) {
-		data.MaxscaleNodes = types.Int64Value(int64(service.MaxscaleNodes))
+	setMaxscaleState(data, service)
+	if diags := setMaintenanceWindowState(ctx, data, service); diags.HasError() {
+		return fmt.Errorf("can not read service maintenance window: %v", diags.Errors())
+	}
+	// Track the configuration the service actually runs, so that a
+	// configuration attached, swapped or detached outside of Terraform shows
+	// up in the plan.
+	data.ConfigID = types.StringNull()
+	if service.ConfigID != "" {
+		data.ConfigID = types.StringValue(service.ConfigID)
 	}
 	if service.Tags != nil && !data.Tags.IsNull() && !data.Tags.IsUnknown() {
 		// Only keep tags whose keys are managed by the user (present in current state).
@@ -893,52 +1184,44 @@ func (r *ServiceResource) Update(ctx context.Context, req resource.UpdateRequest
 	state.WaitForDeletion = plan.WaitForDeletion
 	state.Timeouts = plan.Timeouts
 	state.DeletionProtection = plan.DeletionProtection
+	state.FinalBackup = plan.FinalBackup
+	state.FinalBackupName = plan.FinalBackupName
+	state.IgnoreAllowList = plan.IgnoreAllowList
+	state.RestoreFrom = plan.RestoreFrom
+	// Null and the default value are the same for these, either may be in
+	// state after an import.
+	state.NoSQLEnabled = plan.NoSQLEnabled
+	state.ReplicationEnabled = plan.ReplicationEnabled
+	state.PrimaryHost = plan.PrimaryHost
 	// Save updated data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	r.updateServicePowerState(ctx, plan, state, resp)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	r.updateServiceEndpoints(ctx, plan, state, resp)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	r.updateServiceSize(ctx, plan, state, resp)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	r.updateNumberOfNodeForService(ctx, plan, state, resp)
-	if resp.Diagnostics.HasError() {
+	if !r.saveUpdateProgress(ctx, state, resp) {
 		return
 	}
 
-	r.updateServiceStorage(ctx, plan, state, resp)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	r.updateAllowList(ctx, plan, state, resp)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	r.updateServiceTags(ctx, plan, state, resp)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	r.updateServiceConfig(ctx, plan, state, resp)
-	if resp.Diagnostics.HasError() {
-		return
+	for _, stage := range r.planServiceUpdate(ctx, plan, state) {
+		submitted, alwaysWait := false, false
+		for _, step := range stage {
+			if r.applyServiceUpdateStep(ctx, step, plan, state, resp, submitted) {
+				submitted = true
+				alwaysWait = alwaysWait || step.alwaysWait
+			}
+			if resp.Diagnostics.HasError() || resp.State.Raw.IsNull() {
+				return
+			}
+		}
+		// All changes of a stage are submitted back-to-back and share one wait.
+		if alwaysWait {
+			r.waitForServiceReady(ctx, state, resp)
+		} else if submitted {
+			r.waitForUpdate(ctx, state, resp)
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
+	// Endpoints are matched by name, read back the ones that are declared now.
+	state.Endpoints = plan.Endpoints
 	err := r.readServiceState(ctx, state)
 	if err != nil {
 		if errors.Is(err, skysql.ErrorServiceNotFound) {
@@ -979,223 +1262,294 @@ func (r *ServiceResource) updateAllowListState(plan *ServiceResourceModel, state
 	}
 }
 
-func (r *ServiceResource) updateServiceStorage(ctx context.Context, plan *ServiceResourceModel, state *ServiceResourceModel, resp *resource.UpdateResponse) {
-	if plan.Storage.ValueInt64() != state.Storage.ValueInt64() || plan.VolumeIOPS.ValueInt64() != state.VolumeIOPS.ValueInt64() || plan.VolumeThroughput.ValueInt64() != state.VolumeThroughput.ValueInt64() {
-		tflog.Info(ctx, "Updating storage size for the service", map[string]interface{}{
-			"id":              state.ID.ValueString(),
-			"from":            state.Storage.ValueInt64(),
-			"to":              plan.Storage.ValueInt64(),
-			"iops_from":       state.VolumeIOPS.ValueInt64(),
-			"iops_to":         plan.VolumeIOPS.ValueInt64(),
-			"throughput_from": state.VolumeThroughput.ValueInt64(),
-			"throughput_to":   plan.VolumeThroughput.ValueInt64(),
-		})
-
-		err := r.client.ModifyServiceStorage(ctx, state.ID.ValueString(), plan.Storage.ValueInt64(), plan.VolumeIOPS.ValueInt64(), plan.VolumeThroughput.ValueInt64())
-		if err != nil {
-			resp.Diagnostics.AddError("Error updating a storage for the service",
-				fmt.Sprintf("Unable to update a storage size for the service, got error: %s", err))
+// modifyAllowListOwnerPlan drops allow_list from the plan when the service
+// ignores its allow list, and reports when the allow list is also managed by
+// skysql_allow_list.
+func modifyAllowListOwnerPlan(ctx context.Context, config *ServiceResourceModel, plan *ServiceResourceModel, state *ServiceResourceModel, resp *resource.ModifyPlanResponse) {
+	if plan.IgnoreAllowList.ValueBool() {
+		if !config.AllowList.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("allow_list"),
+				"Conflicting configuration",
+				"allow_list can not be set when ignore_allow_list is true. Manage the allow list with skysql_allow_list instead")
 			return
 		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("allow_list"), types.ListNull(allowListElementType))...)
+		plan.AllowList = types.ListNull(allowListElementType)
+		return
+	}
 
-		state.Storage = plan.Storage
-		state.VolumeIOPS = plan.VolumeIOPS
-		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		r.waitForUpdate(ctx, state, resp)
+	// The allow list is read back once it is no longer ignored.
+	if state != nil && state.IgnoreAllowList.ValueBool() && config.AllowList.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("allow_list"), types.ListUnknown(allowListElementType))...)
+		plan.AllowList = types.ListUnknown(allowListElementType)
+	}
+
+	if !config.AllowList.IsNull() && !plan.ID.IsUnknown() && !plan.ID.IsNull() &&
+		Contains[string](claimAllowList(plan.ID.ValueString(), allowListOwnerService), allowListOwnerAllowList) {
+		summary, detail := allowListOwnerConflict(plan.ID.ValueString())
+		resp.Diagnostics.AddAttributeWarning(path.Root("allow_list"), summary, detail)
 	}
 }
 
-func (r *ServiceResource) updateNumberOfNodeForService(ctx context.Context, plan *ServiceResourceModel, state *ServiceResourceModel, resp *resource.UpdateResponse) {
-	if plan.Nodes.ValueInt64() != state.Nodes.ValueInt64() {
-		tflog.Info(ctx, "Updating number of nodes for the service", map[string]interface{}{
-			"id":   state.ID.ValueString(),
-			"from": state.Nodes.ValueInt64(),
-			"to":   plan.Nodes.ValueInt64(),
-		})
+func (r *ServiceResource) updateServiceStorage(ctx context.Context, plan *ServiceResourceModel, state *ServiceResourceModel, resp *resource.UpdateResponse) bool {
+	if !serviceStorageChanged(ctx, plan, state) {
+		return false
+	}
 
-		err := r.client.ModifyServiceNodeNumber(ctx, state.ID.ValueString(), plan.Nodes.ValueInt64())
-		if err != nil {
-			resp.Diagnostics.AddError("Error updating a number of nodes for the service", fmt.Sprintf("Unable to update a nodes number for the service, got error: %s", err))
-			return
-		}
+	tflog.Info(ctx, "Updating storage size for the service", map[string]interface{}{
+		"id":              state.ID.ValueString(),
+		"from":            state.Storage.ValueInt64(),
+		"to":              plan.Storage.ValueInt64(),
+		"iops_from":       state.VolumeIOPS.ValueInt64(),
+		"iops_to":         plan.VolumeIOPS.ValueInt64(),
+		"throughput_from": state.VolumeThroughput.ValueInt64(),
+		"throughput_to":   plan.VolumeThroughput.ValueInt64(),
+	})
 
-		state.Nodes = plan.Nodes
-		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		r.waitForUpdate(ctx, state, resp)
+	err := r.client.ModifyServiceStorage(ctx, state.ID.ValueString(), plan.Storage.ValueInt64(), plan.VolumeIOPS.ValueInt64(), plan.VolumeThroughput.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating a storage for the service",
+			fmt.Sprintf("Unable to update a storage size for the service, got error: %s", err))
+		return false
 	}
+
+	state.Storage = plan.Storage
+	state.VolumeIOPS = plan.VolumeIOPS
+	state.VolumeThroughput = plan.VolumeThroughput
+	return r.saveUpdateProgress(ctx, state, resp)
 }
 
-func (r *ServiceResource) updateServiceSize(ctx context.Context, plan *ServiceResourceModel, state *ServiceResourceModel, resp *resource.UpdateResponse) {
-	if plan.Size.ValueString() != state.Size.ValueString() {
-		tflog.Info(ctx, "Updating service size", map[string]interface{}{
-			"id":   state.ID.ValueString(),
-			"from": state.Size.ValueString(),
-			"to":   plan.Size.ValueString(),
-		})
+func (r *ServiceResource) updateNumberOfNodeForService(ctx context.Context, plan *ServiceResourceModel, state *ServiceResourceModel, resp *resource.UpdateResponse) bool {
+	if !serviceNodesChanged(ctx, plan, state) {
+		return false
+	}
 
-		err := r.client.ModifyServiceSize(ctx, state.ID.ValueString(), plan.Size.ValueString())
-		if err != nil {
-			resp.Diagnostics.AddError("Error updating service size", fmt.Sprintf("Unable to update service size, got error: %s", err))
-			return
-		}
+	tflog.Info(ctx, "Updating number of nodes for the service", map[string]interface{}{
+		"id":   state.ID.ValueString(),
+		"from": state.Nodes.ValueInt64(),
+		"to":   plan.Nodes.ValueInt64(),
+	})
 
-		state.Size = plan.Size
-		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		r.waitForUpdate(ctx, state, resp)
+	err := r.client.ModifyServiceNodeNumber(ctx, state.ID.ValueString(), plan.Nodes.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating a number of nodes for the service", fmt.Sprintf("Unable to update a nodes number for the service, got error: %s", err))
+		return false
+	}
+
+	state.Nodes = plan.Nodes
+	return r.saveUpdateProgress(ctx, state, resp)
+}
+
+func (r *ServiceResource) updateMaxscaleNodes(ctx context.Context, plan *ServiceResourceModel, state *ServiceResourceModel, resp *resource.UpdateResponse) bool {
+	if !serviceMaxscaleNodesChanged(ctx, plan, state) {
+		return false
+	}
+
+	tflog.Info(ctx, "Updating number of MaxScale nodes for the service", map[string]interface{}{
+		"id":   state.ID.ValueString(),
+		"from": state.MaxscaleNodes.ValueInt64(),
+		"to":   plan.MaxscaleNodes.ValueInt64(),
+	})
+
+	err := r.client.ModifyMaxscaleNodeNumber(ctx, state.ID.ValueString(), plan.MaxscaleNodes.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating a number of MaxScale nodes for the service", fmt.Sprintf("Unable to update a MaxScale nodes number for the service, got error: %s", err))
+		return false
+	}
+
+	state.MaxscaleNodes = plan.MaxscaleNodes
+	return r.saveUpdateProgress(ctx, state, resp)
+}
+
+func (r *ServiceResource) updateMaxscaleSize(ctx context.Context, plan *ServiceResourceModel, state *ServiceResourceModel, resp *resource.UpdateResponse) bool {
+	if !serviceMaxscaleSizeChanged(ctx, plan, state) {
+		return false
+	}
+
+	tflog.Info(ctx, "Updating MaxScale size", map[string]interface{}{
+		"id":   state.ID.ValueString(),
+		"from": state.MaxscaleSize.ValueString(),
+		"to":   plan.MaxscaleSize.ValueString(),
+	})
+
+	err := r.client.ModifyMaxscaleSize(ctx, state.ID.ValueString(), plan.MaxscaleSize.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating MaxScale size", fmt.Sprintf("Unable to update MaxScale size, got error: %s", err))
+		return false
+	}
+
+	state.MaxscaleSize = plan.MaxscaleSize
+	return r.saveUpdateProgress(ctx, state, resp)
+}
+
+func (r *ServiceResource) updateServiceSize(ctx context.Context, plan *ServiceResourceModel, state *ServiceResourceModel, resp *resource.UpdateResponse) bool {
+	if !serviceSizeChanged(ctx, plan, state) {
+		return false
+	}
+
+	tflog.Info(ctx, "Updating service size", map[string]interface{}{
+		"id":   state.ID.ValueString(),
+		"from": state.Size.ValueString(),
+		"to":   plan.Size.ValueString(),
+	})
+
+	err := r.client.ModifyServiceSize(ctx, state.ID.ValueString(), plan.Size.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating service size", fmt.Sprintf("Unable to update service size, got error: %s", err))
+		return false
 	}
+
+	state.Size = plan.Size
+	return r.saveUpdateProgress(ctx, state, resp)
 }
 
-func (r *ServiceResource) updateServiceEndpoints(ctx context.Context, plan *ServiceResourceModel, state *ServiceResourceModel, resp *resource.UpdateResponse) {
+func (r *ServiceResource) updateServiceEndpoints(ctx context.Context, plan *ServiceResourceModel, state *ServiceResourceModel, resp *resource.UpdateResponse) bool {
+	if !serviceEndpointsChanged(ctx, plan, state) {
+		return false
+	}
+	if hasEndpointBlocks(plan) {
+		return r.updateServiceEndpointBlocks(ctx, plan, state, resp)
+	}
+
 	var planAllowedAccounts []string
 	d := plan.AllowedAccounts.ElementsAs(ctx, &planAllowedAccounts, false)
 	if d.HasError() {
-		return
+		resp.Diagnostics.Append(d...)
+		return false
 	}
 
-	var stateAllowedAccounts []string
-	d = state.AllowedAccounts.ElementsAs(ctx, &stateAllowedAccounts, false)
-	if d.HasError() {
-		return
+	tflog.Info(ctx, "Updating service allowed accounts", map[string]interface{}{
+		"id": state.ID.ValueString(),
+	})
+
+	visibility := visibilityPublic
+	if Contains[string](privateConnectMechanisms, plan.Mechanism.ValueString()) {
+		visibility = visibilityPrivate
+	} else {
+		planAllowedAccounts = []string{}
 	}
 
-	isMechanismChanged := plan.Mechanism.ValueString() != state.Mechanism.ValueString()
+	if planAllowedAccounts == nil {
+		planAllowedAccounts = []string{}
+	}
 
-	isAllowedAccountsChanged := !reflect.DeepEqual(planAllowedAccounts, stateAllowedAccounts)
+	endpoint, err := r.client.ModifyServiceEndpoints(ctx,
+		state.ID.ValueString(),
+		plan.Mechanism.ValueString(),
+		planAllowedAccounts,
+		visibility)
+	if err != nil {
+		resp.Diagnostics.AddError("Can not update service", err.Error())
+		return false
+	}
 
-	if isMechanismChanged || isAllowedAccountsChanged {
-		tflog.Info(ctx, "Updating service allowed accounts", map[string]interface{}{
-			"id": state.ID.ValueString(),
-		})
+	state.Mechanism = types.StringValue(endpoint.Mechanism)
+	r.setAllowAccounts(ctx, state, endpoint.AllowedAccounts)
+	state.EndpointService = types.StringValue(endpoint.EndpointService)
 
-		visibility := visibilityPublic
-		if Contains[string](privateConnectMechanisms, plan.Mechanism.ValueString()) {
-			visibility = visibilityPrivate
-		} else {
-			planAllowedAccounts = []string{}
-		}
+	// Save updated data into Terraform state
+	return r.saveUpdateProgress(ctx, state, resp)
+}
 
-		if planAllowedAccounts == nil {
-			planAllowedAccounts = []string{}
-		}
+func (r *ServiceResource) updateAllowList(ctx context.Context, plan *ServiceResourceModel, state *ServiceResourceModel, resp *resource.UpdateResponse) bool {
+	if !serviceAllowListChanged(ctx, plan, state) {
+		return false
+	}
 
-		endpoint, err := r.client.ModifyServiceEndpoints(ctx,
-			state.ID.ValueString(),
-			plan.Mechanism.ValueString(),
-			planAllowedAccounts,
-			visibility)
-		if err != nil {
-			resp.Diagnostics.AddError("Can not update service", err.Error())
-			return
-		}
+	var planAllowList []AllowListModel
+	diags := plan.AllowList.ElementsAs(ctx, &planAllowList, false)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return false
+	}
 
-		state.Mechanism = types.StringValue(endpoint.Mechanism)
-		r.setAllowAccounts(ctx, state, endpoint.AllowedAccounts)
-		state.EndpointService = types.StringValue(endpoint.EndpointService)
+	tflog.Info(ctx, "Updating service allow list", map[string]interface{}{
+		"id": state.ID.ValueString(),
+	})
 
-		// Save updated data into Terraform state
-		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		r.waitForUpdate(ctx, state, resp)
-		return
+	expired, diags := plannedExpiredAllowListEntries(ctx, plan.ExpiredAllowList, planAllowList)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return false
 	}
-}
 
-func (r *ServiceResource) updateAllowList(ctx context.Context, plan *ServiceResourceModel, state *ServiceResourceModel, resp *resource.UpdateResponse) {
-	if !plan.AllowList.IsUnknown() {
-		var planAllowList []AllowListModel
-		diags := plan.AllowList.ElementsAs(ctx, &planAllowList, false)
-		if diags.HasError() {
-			return
-		}
-
-		var stateAllowList []AllowListModel
-		diags = state.AllowList.ElementsAs(ctx, &stateAllowList, false)
-		if diags.HasError() {
-			return
-		}
+	allowListUpdateRequest := make([]provisioning.AllowListItem, 0)
+	for _, entry := range activeAllowListEntries(planAllowList, expired) {
+		allowListUpdateRequest = append(allowListUpdateRequest, allowListItemFromModel(entry))
+	}
 
-		if !reflect.DeepEqual(planAllowList, stateAllowList) {
-			tflog.Info(ctx, "Updating service allow list", map[string]interface{}{
+	unlock := lockAllowList(plan.ID.ValueString())
+	allowListResp, err := r.client.UpdateServiceAllowListByID(ctx, plan.ID.ValueString(), allowListUpdateRequest)
+	unlock()
+	if err != nil {
+		if errors.Is(err, skysql.ErrorServiceNotFound) {
+			tflog.Warn(ctx, "SkySQL service not found, removing from state", map[string]interface{}{
 				"id": state.ID.ValueString(),
 			})
+			resp.State.RemoveResource(ctx)
 
-			allowListUpdateRequest := make([]provisioning.AllowListItem, 0)
-			for i := range planAllowList {
-				allowListUpdateRequest = append(allowListUpdateRequest, provisioning.AllowListItem{
-					IPAddress: planAllowList[i].IPAddress.ValueString(),
-					Comment:   planAllowList[i].Comment.ValueString(),
-				})
-			}
+			return false
+		}
+		resp.Diagnostics.AddError("Error updating service allow list", err.Error())
+		return false
+	}
 
-			allowListResp, err := r.client.UpdateServiceAllowListByID(ctx, plan.ID.ValueString(), allowListUpdateRequest)
-			if err != nil {
-				if errors.Is(err, skysql.ErrorServiceNotFound) {
-					tflog.Warn(ctx, "SkySQL service not found, removing from state", map[string]interface{}{
-						"id": state.ID.ValueString(),
-					})
-					resp.State.RemoveResource(ctx)
+	state.AllowList = plan.AllowList
+	state.ExpiredAllowList = plan.ExpiredAllowList
+	cdiags := r.setAllowListState(ctx, state, allowListResp)
+	if cdiags.HasError() {
+		resp.Diagnostics.Append(cdiags...)
+		return false
+	}
+	r.updateAllowListState(plan, state)
+	// Save updated data into Terraform state
+	return r.saveUpdateProgress(ctx, state, resp)
+}
 
-					return
-				}
-				resp.Diagnostics.AddError("Error updating service allow list", err.Error())
-				return
-			}
+func (r *ServiceResource) updateServicePowerState(ctx context.Context, plan *ServiceResourceModel, state *ServiceResourceModel, resp *resource.UpdateResponse) bool {
+	if !servicePowerStateChanged(ctx, plan, state) {
+		return false
+	}
 
-			var cdiags diag.Diagnostics
-			state.AllowList, cdiags = r.allowListToListType(ctx, allowListResp)
-			if cdiags.HasError() {
-				resp.Diagnostics.Append(cdiags...)
-				return
-			}
-			r.updateAllowListState(plan, state)
-			// Save updated data into Terraform state
-			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
-			if resp.Diagnostics.HasError() {
-				return
-			}
-			r.waitForUpdate(ctx, state, resp)
-			return
-		}
+	tflog.Info(ctx, "Updating service active state", map[string]interface{}{
+		"id":        state.ID.ValueString(),
+		"is_active": plan.IsActive.ValueBool(),
+	})
+	err := r.client.SetServicePowerState(ctx, state.ID.ValueString(), plan.IsActive.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError("Can not update service", err.Error())
+		return false
 	}
+	state.IsActive = plan.IsActive
+	// Save updated data into Terraform state
+	return r.saveUpdateProgress(ctx, state, resp)
 }
 
-func (r *ServiceResource) updateServicePowerState(ctx context.Context, plan *ServiceResourceModel, state *ServiceResourceModel, resp *resource.UpdateResponse) {
-	if !plan.IsActive.IsUnknown() && plan.IsActive.ValueBool() != state.IsActive.ValueBool() {
-		tflog.Info(ctx, "Updating service active state", map[string]interface{}{
-			"id":        state.ID.ValueString(),
-			"is_active": plan.IsActive.ValueBool(),
-		})
-		err := r.client.SetServicePowerState(ctx, state.ID.ValueString(), plan.IsActive.ValueBool())
-		if err != nil {
-			resp.Diagnostics.AddError("Can not update service", err.Error())
-			return
-		}
-		state.IsActive = plan.IsActive
-		// Save updated data into Terraform state
-		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		r.waitForUpdate(ctx, state, resp)
+func (r *ServiceResource) updateServiceSSL(ctx context.Context, plan *ServiceResourceModel, state *ServiceResourceModel, resp *resource.UpdateResponse) bool {
+	if !serviceSSLChanged(ctx, plan, state) {
+		return false
+	}
+
+	tflog.Info(ctx, "Updating service SSL", map[string]interface{}{
+		"id":          state.ID.ValueString(),
+		"ssl_enabled": plan.SSLEnabled.ValueBool(),
+	})
+	err := r.client.SetServiceSSL(ctx, state.ID.ValueString(), plan.SSLEnabled.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating SSL", fmt.Sprintf("Unable to update ssl_enabled, got error: %s", err))
+		return false
 	}
+	state.SSLEnabled = plan.SSLEnabled
+	// Save updated data into Terraform state
+	return r.saveUpdateProgress(ctx, state, resp)
 }
 
-func (r *ServiceResource) updateServiceTags(ctx context.Context, plan *ServiceResourceModel, state *ServiceResourceModel, resp *resource.UpdateResponse) {
+func (r *ServiceResource) updateServiceTags(ctx context.Context, plan *ServiceResourceModel, state *ServiceResourceModel, resp *resource.UpdateResponse) bool {
 	// If user removed tags from config entirely, stop managing them
 	if plan.Tags.IsNull() || plan.Tags.IsUnknown() {
 		state.Tags = plan.Tags
-		return
+		r.saveUpdateProgress(ctx, state, resp)
+		return false
 	}
 
 	var planTags map[string]string
@@ -1205,55 +1559,43 @@ func (r *ServiceResource) updateServiceTags(ctx context.Context, plan *ServiceRe
 			"id":    state.ID.ValueString(),
 			"error": diags.Errors(),
 		})
-		return
+		return false
 	}
 
-	var stateTags map[string]string
-	if !state.Tags.IsNull() && !state.Tags.IsUnknown() {
-		diags = state.Tags.ElementsAs(ctx, &stateTags, false)
-		if diags.HasError() {
-			stateTags = make(map[string]string)
-		}
+	if !serviceTagsChanged(ctx, plan, state) {
+		return false
 	}
 
-	if !reflect.DeepEqual(planTags, stateTags) {
-		tflog.Info(ctx, "Updating service tags", map[string]interface{}{
-			"id": state.ID.ValueString(),
-		})
+	tflog.Info(ctx, "Updating service tags", map[string]interface{}{
+		"id": state.ID.ValueString(),
+	})
 
-		err := r.client.UpdateServiceTags(ctx, state.ID.ValueString(), planTags)
-		if err != nil {
-			if errors.Is(err, skysql.ErrorServiceNotFound) {
-				tflog.Warn(ctx, "SkySQL service not found, removing from state", map[string]interface{}{
-					"id": state.ID.ValueString(),
-				})
-				resp.State.RemoveResource(ctx)
-				return
-			}
-			tflog.Warn(ctx, "Failed to update service tags, continuing with other updates", map[string]interface{}{
-				"id":    state.ID.ValueString(),
-				"error": err.Error(),
+	err := r.client.UpdateServiceTags(ctx, state.ID.ValueString(), planTags)
+	if err != nil {
+		if errors.Is(err, skysql.ErrorServiceNotFound) {
+			tflog.Warn(ctx, "SkySQL service not found, removing from state", map[string]interface{}{
+				"id": state.ID.ValueString(),
 			})
-			return
-		}
-
-		state.Tags = plan.Tags
-		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
-		if resp.Diagnostics.HasError() {
-			return
+			resp.State.RemoveResource(ctx)
+			return false
 		}
-		r.waitForUpdate(ctx, state, resp)
+		tflog.Warn(ctx, "Failed to update service tags, continuing with other updates", map[string]interface{}{
+			"id":    state.ID.ValueString(),
+			"error": err.Error(),
+		})
+		return false
 	}
-}
 
-func (r *ServiceResource) updateServiceConfig(ctx context.Context, plan *ServiceResourceModel, state *ServiceResourceModel, resp *resource.UpdateResponse) {
-	planConfigID := plan.ConfigID.ValueString()
-	stateConfigID := state.ConfigID.ValueString()
+	state.Tags = plan.Tags
+	return r.saveUpdateProgress(ctx, state, resp)
+}
 
-	if planConfigID == stateConfigID {
-		return
+func (r *ServiceResource) updateServiceConfig(ctx context.Context, plan *ServiceResourceModel, state *ServiceResourceModel, resp *resource.UpdateResponse) bool {
+	if !serviceConfigChanged(ctx, plan, state) {
+		return false
 	}
 
+	planConfigID := plan.ConfigID.ValueString()
 	serviceID := state.ID.ValueString()
 
 	// Check the actual service state to avoid applying the same config
@@ -1261,25 +1603,25 @@ func (r *ServiceResource) updateServiceConfig(ctx context.Context, plan *Service
 	service, err := r.client.GetServiceByID(ctx, serviceID)
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading service", err.Error())
-		return
+		return false
 	}
 
 	if plan.ConfigID.IsNull() || planConfigID == "" {
 		if service.ConfigID == "" {
 			// Already using default config — nothing to do.
 			state.ConfigID = types.StringNull()
-			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
-			return
+			r.saveUpdateProgress(ctx, state, resp)
+			return false
 		}
 		// Config removed — revert to default.
 		tflog.Info(ctx, "Removing configuration from service", map[string]interface{}{
 			"service_id": serviceID,
 		})
-		err := r.client.RemoveConfigFromService(ctx, serviceID)
+		err := r.removeServiceConfig(ctx, serviceID, service.ConfigID)
 		if err != nil {
 			resp.Diagnostics.AddError("Error removing configuration from service",
 				fmt.Sprintf("Unable to remove config from service %q: %s", serviceID, err.Error()))
-			return
+			return false
 		}
 		state.ConfigID = types.StringNull()
 	} else {
@@ -1290,54 +1632,105 @@ func (r *ServiceResource) updateServiceConfig(ctx context.Context, plan *Service
 				"config_id":  planConfigID,
 			})
 			state.ConfigID = types.StringValue(planConfigID)
-			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
-			return
+			r.saveUpdateProgress(ctx, state, resp)
+			return false
 		}
 		// Config changed or added.
 		tflog.Info(ctx, "Applying configuration to service", map[string]interface{}{
 			"service_id": serviceID,
 			"config_id":  planConfigID,
 		})
-		err := r.client.ApplyConfigToService(ctx, serviceID, planConfigID)
+		err := r.applyServiceConfig(ctx, serviceID, planConfigID)
 		if err != nil {
 			resp.Diagnostics.AddError("Error applying configuration to service",
 				fmt.Sprintf("Unable to apply config %q to service %q: %s", planConfigID, serviceID, err.Error()))
-			return
+			return false
 		}
 		state.ConfigID = types.StringValue(planConfigID)
 	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
-	if resp.Diagnostics.HasError() {
-		return
+	return r.saveUpdateProgress(ctx, state, resp)
+}
+
+// applyServiceConfig applies a configuration to a service. The API answers
+// with not found for a deleted configuration as well, that case is reported
+// with its own error instead of as a missing service.
+func (r *ServiceResource) applyServiceConfig(ctx context.Context, serviceID string, configID string) error {
+	err := r.client.ApplyConfigToService(ctx, serviceID, configID)
+	if !errors.Is(err, skysql.ErrorServiceNotFound) {
+		return err
+	}
+	if _, getErr := r.client.GetConfigByID(ctx, configID); errors.Is(getErr, skysql.ErrorServiceNotFound) {
+		return fmt.Errorf("configuration %q does not exist, it may have been deleted outside of Terraform. "+
+			"Point config_id to an existing configuration or remove it to revert the service to its default configuration", configID)
+	}
+	return err
+}
+
+// removeServiceConfig reverts a service to its default configuration. The API
+// answers with not found when the configuration was deleted, that case only
+// succeeds once the service no longer reports the configuration.
+func (r *ServiceResource) removeServiceConfig(ctx context.Context, serviceID string, configID string) error {
+	err := r.client.RemoveConfigFromService(ctx, serviceID)
+	if !errors.Is(err, skysql.ErrorServiceNotFound) {
+		return err
+	}
+	if _, getErr := r.client.GetConfigByID(ctx, configID); !errors.Is(getErr, skysql.ErrorServiceNotFound) {
+		return err
+	}
+	service, getErr := r.client.GetServiceByID(ctx, serviceID)
+	if getErr != nil {
+		return getErr
 	}
-	r.waitForUpdate(ctx, state, resp)
+	if service.ConfigID != "" {
+		return fmt.Errorf("configuration %q was deleted outside of Terraform but the service still reports it. "+
+			"Apply an existing configuration with config_id to replace it", service.ConfigID)
+	}
+	tflog.Warn(ctx, "Configuration of the service no longer exists, nothing to remove", map[string]interface{}{
+		"service_id": serviceID,
+		"config_id":  configID,
+	})
+	return nil
+}
+
+// saveUpdateProgress writes the values confirmed by a completed update step
+// into the Terraform state. Update runs several independent API calls in a row;
+// saving after each of them means that a failure in a later step leaves the
+// state accurate, and the next apply only re-issues the steps that are left.
+func (r *ServiceResource) saveUpdateProgress(ctx context.Context, state *ServiceResourceModel, resp *resource.UpdateResponse) bool {
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+	return !resp.Diagnostics.HasError()
 }
 
 var serviceUpdateWaitStates = []string{"ready", "failed", "stopped"}
 
 func (r *ServiceResource) waitForUpdate(ctx context.Context, state *ServiceResourceModel, resp *resource.UpdateResponse) {
 	if state.WaitForUpdate.ValueBool() {
-		err := sdkresource.RetryContext(ctx, defaultUpdateTimeout, func() *sdkresource.RetryError {
-			service, err := r.client.GetServiceByID(ctx, state.ID.ValueString())
-			if err != nil {
-				return sdkresource.NonRetryableError(fmt.Errorf("error retrieving service details: %v", err))
-			}
-
-			if Contains[string](serviceUpdateWaitStates, service.Status) {
-				return nil
-			}
+		r.waitForServiceReady(ctx, state, resp)
+	}
+}
 
-			if service.Status == "failed" {
-				return sdkresource.NonRetryableError(errors.New("service creation failed"))
-			}
+// waitForServiceReady waits for the service to settle regardless of wait_for_update.
+func (r *ServiceResource) waitForServiceReady(ctx context.Context, state *ServiceResourceModel, resp *resource.UpdateResponse) {
+	err := sdkresource.RetryContext(ctx, defaultUpdateTimeout, func() *sdkresource.RetryError {
+		service, err := r.client.GetServiceByID(ctx, state.ID.ValueString())
+		if err != nil {
+			return sdkresource.NonRetryableError(fmt.Errorf("error retrieving service details: %v", err))
+		}
 
-			return sdkresource.RetryableError(fmt.Errorf("expected instance to be ready or failed or stopped state but was in state %s", service.Status))
-		})
+		if Contains[string](serviceUpdateWaitStates, service.Status) {
+			return nil
+		}
 
-		if err != nil {
-			resp.Diagnostics.AddError("Error updating service", fmt.Sprintf("Unable to update service, got error: %s", err))
+		if service.Status == "failed" {
+			return sdkresource.NonRetryableError(errors.New("service creation failed"))
 		}
+
+		return sdkresource.RetryableError(fmt.Errorf("expected instance to be ready or failed or stopped state but was in state %s", service.Status))
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating service", fmt.Sprintf("Unable to update service, got error: %s", err))
 	}
 }
 
@@ -1356,6 +1749,10 @@ func (r *ServiceResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
+	if state.FinalBackup.ValueBool() && !r.takeFinalBackup(ctx, state, resp) {
+		return
+	}
+
 	err := r.client.DeleteServiceByID(ctx, state.ID.ValueString())
 	if err != nil {
 		if errors.Is(err, skysql.ErrorServiceNotFound) {
@@ -1394,8 +1791,72 @@ func (r *ServiceResource) Delete(ctx context.Context, req resource.DeleteRequest
 	}
 }
 
+// takeFinalBackup takes a full backup of the service and waits for it to
+// complete. It reports whether the service can be deleted.
+func (r *ServiceResource) takeFinalBackup(ctx context.Context, state *ServiceResourceModel, resp *resource.DeleteResponse) bool {
+	serviceID := state.ID.ValueString()
+	tflog.Info(ctx, "Taking a final backup of the service", map[string]interface{}{
+		"id":   serviceID,
+		"name": state.FinalBackupName.ValueString(),
+	})
+
+	finalBackup, err := r.client.CreateBackup(ctx, &backup.CreateBackupRequest{
+		ServiceID: serviceID,
+		Type:      backup.TypeFull,
+		Name:      state.FinalBackupName.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error taking final backup",
+			fmt.Sprintf("Unable to start the final backup of service %q, the service was not deleted: %s", serviceID, err))
+		return false
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return false
+	}
+
+	err = sdkresource.RetryContext(ctx, deleteTimeout, func() *sdkresource.RetryError {
+		b, err := r.client.GetBackupByID(ctx, finalBackup.ID)
+		if err != nil {
+			return sdkresource.NonRetryableError(fmt.Errorf("error retrieving backup details: %v", err))
+		}
+
+		switch b.Status {
+		case backup.StatusSucceeded:
+			return nil
+		case backup.StatusFailed:
+			return sdkresource.NonRetryableError(errors.New("backup failed"))
+		}
+
+		return sdkresource.RetryableError(fmt.Errorf("expected backup to be succeeded or failed but was in state %s", b.Status))
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error taking final backup",
+			fmt.Sprintf("Final backup %q of service %q did not complete, the service was not deleted: %s", finalBackup.ID, serviceID, err))
+		return false
+	}
+
+	resp.Diagnostics.AddWarning("Final backup completed",
+		fmt.Sprintf("Final backup %q of service %q completed before the service was deleted.", finalBackup.ID, serviceID))
+	return true
+}
+
 func (r *ServiceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	id, err := resolveServiceImportID(ctx, r.client, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Can not import resource", err.Error())
+		return
+	}
+
+	service, err := r.client.GetServiceByID(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError("Can not import resource", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(importServiceState(ctx, service, &resp.State)...)
 }
 
 func (r *ServiceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
@@ -1441,6 +1902,14 @@ func (r *ServiceResource) ModifyPlan(ctx context.Context, req resource.ModifyPla
 		return
 	}
 
+	if !config.FinalBackupName.IsNull() && !config.FinalBackup.IsUnknown() && !config.FinalBackup.ValueBool() {
+		resp.Diagnostics.AddAttributeWarning(path.Root("final_backup_name"),
+			"final_backup_name has no effect",
+			"final_backup_name is only used when final_backup is set to true.")
+	}
+
+	validateRestoreFromPlan(ctx, plan, resp)
+
 	if !Contains[string]([]string{"gcp", "aws", "azure"}, plan.Provider.ValueString()) {
 		resp.Diagnostics.AddAttributeError(path.Root("provider"),
 			"Invalid provider value",
@@ -1589,6 +2058,12 @@ func (r *ServiceResource) ModifyPlan(ctx context.Context, req resource.ModifyPla
 				"Attempt to modify read-only attribute",
 				fmt.Sprintf("The argument %q is read only for the %q topology", "version", plan.Topology.ValueString()))
 		}
+
+		if state != nil && !plan.SSLEnabled.IsUnknown() && plan.SSLEnabled.ValueBool() != state.SSLEnabled.ValueBool() {
+			resp.Diagnostics.AddAttributeError(path.Root("ssl_enabled"),
+				"Attempt to modify read-only attribute",
+				fmt.Sprintf("The argument %q is read only for the %q topology", "ssl_enabled", plan.Topology.ValueString()))
+		}
 	}
 
 	// Block start/stop operations for serverless-standalone services
@@ -1614,10 +2089,18 @@ func (r *ServiceResource) ModifyPlan(ctx context.Context, req resource.ModifyPla
 				"Please explicitly destroy this service before changing its architecture.")
 	}
 
-	if state != nil && plan.SSLEnabled.ValueBool() != state.SSLEnabled.ValueBool() {
-		resp.Diagnostics.AddError("Cannot change service ssl_enabled",
-			"To prevent accidental deletion of data, changing ssl_enabled isn't allowed. "+
-				"Please explicitly destroy this service before changing its ssl_enabled.")
+	if state != nil && !plan.SSLEnabled.IsUnknown() && plan.SSLEnabled.ValueBool() != state.SSLEnabled.ValueBool() {
+		if plan.SSLEnabled.ValueBool() {
+			resp.Diagnostics.AddAttributeWarning(path.Root("ssl_enabled"),
+				"Enabling SSL affects connected clients",
+				"The service will only accept TLS connections once ssl_enabled is applied. "+
+					"Existing clients that connect without TLS will be disconnected and fail to reconnect until they enable TLS.")
+		} else {
+			resp.Diagnostics.AddAttributeWarning(path.Root("ssl_enabled"),
+				"Disabling SSL affects connected clients",
+				"The service will stop accepting TLS connections once ssl_enabled is applied. "+
+					"Existing clients that require TLS will be disconnected and fail to reconnect until they connect without TLS.")
+		}
 	}
 
 	if state != nil && plan.Version.ValueString() != state.Version.ValueString() {
@@ -1643,13 +2126,20 @@ func (r *ServiceResource) ModifyPlan(ctx context.Context, req resource.ModifyPla
 	// Preserve allow_list from state when it's computed or null in plan
 	if state != nil && !state.AllowList.IsUnknown() && (plan.AllowList.IsNull() || plan.AllowList.IsUnknown()) {
 		resp.Plan.SetAttribute(ctx, path.Root("allow_list"), state.AllowList)
+		plan.AllowList = state.AllowList
 	}
 
+	modifyAllowListOwnerPlan(ctx, config, plan, state, resp)
+	modifyExpiredAllowListPlan(ctx, plan, state, resp)
+
 	// Preserve endpoint_service from state when it's computed, but only if mechanism isn't changing
 	if state != nil && !state.EndpointService.IsUnknown() && plan.EndpointService.IsUnknown() &&
 		plan.Mechanism.ValueString() == state.Mechanism.ValueString() {
 		resp.Plan.SetAttribute(ctx, path.Root("endpoint_service"), state.EndpointService)
 	}
+
+	r.modifyEndpointsPlan(ctx, config, plan, state, resp)
+	modifyConnectionPlan(ctx, plan, state, resp)
 }
 
 func (r *ServiceResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
@@ -1665,9 +2155,13 @@ func (r *ServiceResource) UpgradeState(ctx context.Context) map[int64]resource.S
 				}
 				if state.Provider.ValueString() == "gcp" {
 					state.VolumeType = types.StringValue("pd-ssd")
-					diags = resp.State.Set(ctx, state)
-					resp.Diagnostics.Append(diags...)
 				}
+				resp.Diagnostics.Append(upgradeEndpointState(ctx, &state)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				diags = resp.State.Set(ctx, state)
+				resp.Diagnostics.Append(diags...)
 			},
 		},
 		1: {
@@ -1715,10 +2209,42 @@ func (r *ServiceResource) UpgradeState(ctx context.Context) map[int64]resource.S
 					AvailabilityZone:   oldState.AvailabilityZone,
 					Tags:               oldState.Tags,
 					ConfigID:           oldState.ConfigID,
+					Endpoints:          types.ListValueMust(serviceEndpointElementType, []attr.Value{}),
+					Ports:              types.ListNull(servicePortElementType),
+					OutboundIps:        types.ListNull(types.StringType),
+					ReadWritePort:      types.Int64Null(),
+					ReadOnlyPort:       types.Int64Null(),
+					ConnectionURIs:     types.MapNull(types.StringType),
+					FinalBackup:        types.BoolValue(false),
+					FinalBackupName:    types.StringNull(),
+					RestoreFrom:        types.ObjectNull(restoreFromAttrTypes),
+					MaintenanceWindow:  types.ObjectNull(maintenanceWindowAttrTypes),
+					ExpiredAllowList:   types.ListNull(allowListElementType),
+					IgnoreAllowList:    types.BoolValue(false),
+				}
+				resp.Diagnostics.Append(upgradeEndpointState(ctx, &newState)...)
+				if resp.Diagnostics.HasError() {
+					return
 				}
 				diags = resp.State.Set(ctx, newState)
 				resp.Diagnostics.Append(diags...)
 			},
 		},
+		// Version 2 has the current attributes except for the endpoint blocks.
+		2: {
+			PriorSchema: &serviceResourceSchemaV0,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var state ServiceResourceModel
+				resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				resp.Diagnostics.Append(upgradeEndpointState(ctx, &state)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+			},
+		},
 	}
 }
@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -336,7 +337,8 @@ func TestServiceResourceConfigID_SameConfigNoOp(t *testing.T) {
 	})
 
 	// Step 2: Add config_id — but the service already has this config applied
-	// (simulates import scenario where TF state is empty but service has config).
+	// (set externally in the portal). The refresh picks it up, so there is
+	// nothing to update.
 
 	// Terraform Read (pre-plan refresh)
 	expectRequest(func(w http.ResponseWriter, req *http.Request) {
@@ -349,27 +351,7 @@ func TestServiceResourceConfigID_SameConfigNoOp(t *testing.T) {
 		serviceWithConfig.ConfigID = configID
 		json.NewEncoder(w).Encode(&serviceWithConfig)
 	})
-	// Update: GET /services/{id} to check actual config (updateServiceConfig)
-	expectRequest(func(w http.ResponseWriter, req *http.Request) {
-		r := require.New(t)
-		r.Equal(http.MethodGet, req.Method)
-		r.Equal("/provisioning/v1/services/"+serviceID, req.URL.Path)
-		w.Header().Set("Content-Type", "application/json")
-		serviceWithConfig := *service
-		serviceWithConfig.ConfigID = configID
-		json.NewEncoder(w).Encode(&serviceWithConfig)
-	})
-	// readServiceState at end of Update
-	expectRequest(func(w http.ResponseWriter, req *http.Request) {
-		r := require.New(t)
-		r.Equal(http.MethodGet, req.Method)
-		r.Equal("/provisioning/v1/services/"+serviceID, req.URL.Path)
-		w.Header().Set("Content-Type", "application/json")
-		serviceWithConfig := *service
-		serviceWithConfig.ConfigID = configID
-		json.NewEncoder(w).Encode(&serviceWithConfig)
-	})
-	// Terraform Read after Update
+	// Terraform Read (verify no diff)
 	expectRequest(func(w http.ResponseWriter, req *http.Request) {
 		r := require.New(t)
 		r.Equal(http.MethodGet, req.Method)
@@ -447,7 +429,7 @@ func TestServiceResourceConfigID_SameConfigNoOp(t *testing.T) {
 			},
 			{
 				// Add config_id that the service already has — should be a no-op
-				// (no POST /services/{id}/config call — only GETs).
+				// (no update at all — only GETs).
 				Config: serviceHCLWithConfig,
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("skysql_service.default", "id", serviceID),
@@ -865,3 +847,241 @@ func TestServiceResourceConfigID_RemoveConfig(t *testing.T) {
 		},
 	})
 }
+
+// TestServiceResourceConfigID_DetachedOutsideTerraform verifies that a
+// configuration detached in the portal is noticed by the refresh and
+// attached again.
+func TestServiceResourceConfigID_DetachedOutsideTerraform(t *testing.T) {
+	configureOnce.Reset()
+
+	const serviceID = "dbdgf42002424"
+	const configID = "cfg-detached"
+
+	testUrl, expectRequest, close := mockSkySQLAPI(t)
+	defer close()
+	os.Setenv("TF_SKYSQL_API_KEY", "[api-key]")
+	os.Setenv("TF_SKYSQL_API_BASE_URL", testUrl)
+
+	service := &provisioning.Service{
+		ID:           serviceID,
+		Name:         "test-detached-config",
+		Region:       "us-central1",
+		Provider:     "gcp",
+		Tier:         "power",
+		Topology:     "es-single",
+		Version:      "10.6.11-6-1",
+		Architecture: "amd64",
+		Size:         "sky-2x8",
+		Nodes:        1,
+		SSLEnabled:   true,
+		Status:       "ready",
+		CreatedOn:    int(time.Now().Unix()),
+		UpdatedOn:    int(time.Now().Unix()),
+		CreatedBy:    uuid.New().String(),
+		UpdatedBy:    uuid.New().String(),
+		Endpoints: []provisioning.Endpoint{
+			{
+				Name: "primary",
+				Ports: []provisioning.Port{
+					{Name: "readwrite", Port: 3306, Purpose: "readwrite"},
+				},
+			},
+		},
+		StorageVolume: struct {
+			Size       int    `json:"size"`
+			VolumeType string `json:"volume_type"`
+			IOPS       int    `json:"iops"`
+			Throughput int    `json:"throughput"`
+		}{Size: 100, VolumeType: "pd-ssd"},
+		IsActive:    true,
+		ServiceType: "transactional",
+	}
+
+	serviceWithConfig := *service
+	serviceWithConfig.ConfigID = configID
+
+	encode := func(service *provisioning.Service) http.HandlerFunc {
+		return func(w http.ResponseWriter, req *http.Request) {
+			r := require.New(t)
+			r.Equal(http.MethodGet, req.Method)
+			r.Equal("/provisioning/v1/services/"+serviceID, req.URL.Path)
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(service)
+		}
+	}
+	applyConfig := func(w http.ResponseWriter, req *http.Request) {
+		r := require.New(t)
+		r.Equal(http.MethodPost, req.Method)
+		r.Equal("/provisioning/v1/services/"+serviceID+"/config", req.URL.Path)
+		var payload provisioning.ServiceConfigState
+		json.NewDecoder(req.Body).Decode(&payload)
+		r.Equal(configID, payload.ConfigID)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(&payload)
+	}
+
+	// --- Step 1: Create service with config_id ---
+
+	// Provider configure
+	expectRequest(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]provisioning.Version{})
+	})
+	// Create: POST /services
+	expectRequest(func(w http.ResponseWriter, req *http.Request) {
+		require.New(t).Equal(http.MethodPost, req.Method)
+		w.Header().Set("Content-Type", "application/json")
+		pendingService := *service
+		pendingService.Status = "pending_create"
+		json.NewEncoder(w).Encode(&pendingService)
+	})
+	// Wait for creation and readServiceState after wait
+	expectRequest(encode(service))
+	expectRequest(encode(service))
+	// Apply config, wait for it and read after create
+	expectRequest(applyConfig)
+	expectRequest(encode(&serviceWithConfig))
+	expectRequest(encode(&serviceWithConfig))
+
+	// --- Step 2: The configuration was detached in the portal ---
+
+	// Terraform Read (pre-plan refresh) notices the detached configuration
+	expectRequest(encode(service))
+	// Update: GET /services/{id} to check actual config
+	expectRequest(encode(service))
+	// Attach the configuration again
+	expectRequest(applyConfig)
+	// Wait for update, readServiceState at end of Update and verify no diff
+	for i := 0; i < 3; i++ {
+		expectRequest(encode(&serviceWithConfig))
+	}
+
+	// Destroy
+	expectRequest(func(w http.ResponseWriter, req *http.Request) {
+		require.New(t).Equal(http.MethodDelete, req.Method)
+		w.WriteHeader(http.StatusAccepted)
+	})
+	expectRequest(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(&skysql.ErrorResponse{Code: http.StatusNotFound})
+	})
+
+	serviceHCL := fmt.Sprintf(`
+	resource "skysql_service" "default" {
+		service_type        = "transactional"
+		topology            = "es-single"
+		cloud_provider      = "gcp"
+		region              = "us-central1"
+		name                = "test-detached-config"
+		architecture        = "amd64"
+		nodes               = 1
+		size                = "sky-2x8"
+		storage             = 100
+		ssl_enabled         = true
+		version             = "10.6.11-6-1"
+		wait_for_creation   = true
+		wait_for_deletion   = true
+		deletion_protection = false
+		config_id           = "%s"
+	}`, configID)
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"skysql": providerserver.NewProtocol6WithError(New("")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: serviceHCL,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("skysql_service.default", "config_id", configID),
+				),
+			},
+			{
+				Config: serviceHCL,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("skysql_service.default", "config_id", configID),
+				),
+			},
+		},
+	})
+}
+
+// TestApplyServiceConfig_DeletedConfig verifies that applying a configuration
+// that no longer exists is reported as such and not as a missing service.
+func TestApplyServiceConfig_DeletedConfig(t *testing.T) {
+	const serviceID = "dbdgf42002425"
+	const configID = "cfg-deleted"
+
+	testUrl, expectRequest, close := mockSkySQLAPI(t)
+	defer close()
+
+	r := require.New(t)
+
+	notFound := func(method string, path string) http.HandlerFunc {
+		return func(w http.ResponseWriter, req *http.Request) {
+			r.Equal(method, req.Method)
+			r.Equal(path, req.URL.Path)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(&skysql.ErrorResponse{Code: http.StatusNotFound})
+		}
+	}
+	expectRequest(notFound(http.MethodPost, "/provisioning/v1/services/"+serviceID+"/config"))
+	expectRequest(notFound(http.MethodGet, "/provisioning/v1/configs/"+configID))
+
+	service := &ServiceResource{client: skysql.New(testUrl, "[api-key]", "")}
+	err := service.applyServiceConfig(context.Background(), serviceID, configID)
+	r.ErrorContains(err, `configuration "cfg-deleted" does not exist`)
+}
+
+// TestRemoveServiceConfig_DeletedConfig verifies that removing a configuration
+// that no longer exists only succeeds once the service no longer reports it.
+func TestRemoveServiceConfig_DeletedConfig(t *testing.T) {
+	const serviceID = "dbdgf42002426"
+	const configID = "cfg-deleted"
+
+	r := require.New(t)
+
+	for _, test := range []struct {
+		name          string
+		serviceConfig string
+		expectError   string
+	}{
+		{name: "detached", serviceConfig: ""},
+		{name: "still attached", serviceConfig: configID, expectError: `configuration "cfg-deleted" was deleted outside of Terraform`},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			testUrl, expectRequest, close := mockSkySQLAPI(t)
+			defer close()
+
+			notFound := func(method string, path string) http.HandlerFunc {
+				return func(w http.ResponseWriter, req *http.Request) {
+					r.Equal(method, req.Method)
+					r.Equal(path, req.URL.Path)
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusNotFound)
+					json.NewEncoder(w).Encode(&skysql.ErrorResponse{Code: http.StatusNotFound})
+				}
+			}
+			expectRequest(notFound(http.MethodDelete, "/provisioning/v1/services/"+serviceID+"/config"))
+			expectRequest(notFound(http.MethodGet, "/provisioning/v1/configs/"+configID))
+			expectRequest(func(w http.ResponseWriter, req *http.Request) {
+				r.Equal(http.MethodGet, req.Method)
+				r.Equal("/provisioning/v1/services/"+serviceID, req.URL.Path)
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				json.NewEncoder(w).Encode(&provisioning.Service{ID: serviceID, ConfigID: test.serviceConfig})
+			})
+
+			service := &ServiceResource{client: skysql.New(testUrl, "[api-key]", "")}
+			err := service.removeServiceConfig(context.Background(), serviceID, configID)
+			if test.expectError == "" {
+				r.NoError(err)
+			} else {
+				r.ErrorContains(err, test.expectError)
+			}
+		})
+	}
+}
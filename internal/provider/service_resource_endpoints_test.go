@@ -0,0 +1,275 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql/provisioning"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceResourceEndpoints(t *testing.T) {
+	const serviceID = "dbdgf42002418"
+	const endpointService = "com.amazonaws.vpce.us-east-2.vpce-svc-0123456789abcdef0"
+
+	testUrl, expectRequest, close := mockSkySQLAPI(t)
+	defer close()
+	os.Setenv("TF_SKYSQL_API_KEY", "[api-key]")
+	os.Setenv("TF_SKYSQL_API_BASE_URL", testUrl)
+
+	r := require.New(t)
+
+	configureOnce.Reset()
+	var service *provisioning.Service
+
+	getService := func(w http.ResponseWriter, req *http.Request) {
+		r.Equal(
+			fmt.Sprintf("%s %s/%s", http.MethodGet, "/provisioning/v1/services", serviceID),
+			fmt.Sprintf("%s %s", req.Method, req.URL.Path))
+		w.Header().Set("Content-Type", "application/json")
+		service.Status = "ready"
+		json.NewEncoder(w).Encode(&service)
+		w.WriteHeader(http.StatusOK)
+	}
+
+	patchEndpoints := func(check func(payload provisioning.PatchServiceEndpointsRequest)) func(w http.ResponseWriter, req *http.Request) {
+		return func(w http.ResponseWriter, req *http.Request) {
+			r.Equal(
+				fmt.Sprintf("%s %s/%s/endpoints", http.MethodPatch, "/provisioning/v1/services", serviceID),
+				fmt.Sprintf("%s %s", req.Method, req.URL.Path))
+			w.Header().Set("Content-Type", "application/json")
+			payload := provisioning.PatchServiceEndpointsRequest{}
+			err := json.NewDecoder(req.Body).Decode(&payload)
+			r.NoError(err)
+			check(payload)
+
+			ports := map[string][]provisioning.Port{}
+			for _, endpoint := range service.Endpoints {
+				ports[endpoint.Name] = endpoint.Ports
+			}
+			service.Endpoints = make([]provisioning.Endpoint, 0, len(payload))
+			for i := range payload {
+				endpoint := provisioning.Endpoint{
+					Name:            payload[i].Name,
+					Mechanism:       payload[i].Mechanism,
+					AllowedAccounts: payload[i].AllowedAccounts,
+					Visibility:      payload[i].Visibility,
+					Ports:           ports[payload[i].Name],
+				}
+				if payload[i].AllowList != nil {
+					endpoint.AllowList = *payload[i].AllowList
+				}
+				if payload[i].Mechanism == "privateconnect" {
+					endpoint.EndpointService = endpointService
+					payload[i].EndpointService = endpointService
+				}
+				service.Endpoints = append(service.Endpoints, endpoint)
+			}
+			json.NewEncoder(w).Encode(payload)
+			w.WriteHeader(http.StatusOK)
+		}
+	}
+
+	// Check API connectivity
+	expectRequest(func(w http.ResponseWriter, req *http.Request) {
+		r.Equal(http.MethodGet, req.Method)
+		r.Equal("/provisioning/v1/versions", req.URL.Path)
+		r.Equal("page_size=1", req.URL.RawQuery)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode([]provisioning.Version{})
+	})
+	// Create service with the primary endpoint
+	expectRequest(func(w http.ResponseWriter, req *http.Request) {
+		r.Equal(http.MethodPost, req.Method)
+		r.Equal("/provisioning/v1/services", req.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		payload := provisioning.CreateServiceRequest{}
+		err := json.NewDecoder(req.Body).Decode(&payload)
+		r.NoError(err)
+		r.Equal("nlb", payload.Mechanism)
+		r.Empty(payload.AllowedAccounts)
+		r.Equal([]provisioning.AllowListItem{{IPAddress: "192.168.0.1/32", Comment: "office"}}, payload.AllowList)
+		service = &provisioning.Service{
+			ID:           serviceID,
+			Name:         payload.Name,
+			Region:       payload.Region,
+			Provider:     payload.Provider,
+			Tier:         "foundation",
+			Topology:     payload.Topology,
+			Version:      payload.Version,
+			Architecture: payload.Architecture,
+			Size:         payload.Size,
+			Nodes:        int(payload.Nodes),
+			SSLEnabled:   payload.SSLEnabled,
+			NosqlEnabled: payload.NoSQLEnabled,
+			Status:       "pending_create",
+			FQDN:         "dbdgf42002418.sysp0000.db1.skysql.com",
+			OutboundIps:  []string{"3.136.12.34", "3.136.12.35"},
+			CreatedOn:    int(time.Now().Unix()),
+			UpdatedOn:    int(time.Now().Unix()),
+			CreatedBy:    uuid.New().String(),
+			UpdatedBy:    uuid.New().String(),
+			Endpoints: []provisioning.Endpoint{
+				{
+					Name:       "primary",
+					Mechanism:  payload.Mechanism,
+					Visibility: "public",
+					AllowList:  payload.AllowList,
+					Ports: []provisioning.Port{
+						{
+							Name:    "readwrite",
+							Port:    3306,
+							Purpose: "readwrite",
+						},
+					},
+				},
+			},
+			StorageVolume: struct {
+				Size       int    `json:"size"`
+				VolumeType string `json:"volume_type"`
+				IOPS       int    `json:"iops"`
+				Throughput int    `json:"throughput"`
+			}{
+				Size:       int(payload.Storage),
+				VolumeType: payload.VolumeType,
+				IOPS:       int(payload.VolumeIOPS),
+			},
+			IsActive:    true,
+			ServiceType: payload.ServiceType,
+		}
+		json.NewEncoder(w).Encode(service)
+		w.WriteHeader(http.StatusCreated)
+	})
+	// Wait for creation and read the service back
+	expectRequest(getService)
+	expectRequest(getService)
+	// Add the private endpoint once the service is ready
+	expectRequest(patchEndpoints(func(payload provisioning.PatchServiceEndpointsRequest) {
+		r.Len(payload, 2)
+		r.Equal("primary", payload[0].Name)
+		r.Equal("nlb", payload[0].Mechanism)
+		r.Equal("public", payload[0].Visibility)
+		r.Equal("private", payload[1].Name)
+		r.Equal("privateconnect", payload[1].Mechanism)
+		r.Equal("private", payload[1].Visibility)
+		r.Equal([]string{"123456789012"}, payload[1].AllowedAccounts)
+		r.Nil(payload[1].AllowList)
+	}))
+	// Wait for the endpoints, refresh state and refresh before the update
+	for i := 0; i < 3; i++ {
+		expectRequest(getService)
+	}
+	// Only the changed endpoint settings differ, both endpoints are sent by name
+	expectRequest(patchEndpoints(func(payload provisioning.PatchServiceEndpointsRequest) {
+		r.Len(payload, 2)
+		r.Equal("primary", payload[0].Name)
+		r.NotNil(payload[0].AllowList)
+		r.Equal([]provisioning.AllowListItem{{IPAddress: "192.168.0.1/32", Comment: "office"}}, *payload[0].AllowList)
+		r.Equal("private", payload[1].Name)
+		r.Equal([]string{"123456789012", "210987654321"}, payload[1].AllowedAccounts)
+	}))
+	// Wait for update, read the service back and refresh state
+	for i := 0; i < 3; i++ {
+		expectRequest(getService)
+	}
+	expectRequest(func(w http.ResponseWriter, req *http.Request) {
+		r.Equal(
+			fmt.Sprintf("%s %s/%s", http.MethodDelete, "/provisioning/v1/services", serviceID),
+			fmt.Sprintf("%s %s", req.Method, req.URL.Path))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+	})
+	expectRequest(func(w http.ResponseWriter, req *http.Request) {
+		r.Equal(
+			fmt.Sprintf("%s %s/%s", http.MethodGet, "/provisioning/v1/services", serviceID),
+			fmt.Sprintf("%s %s", req.Method, req.URL.Path))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(&skysql.ErrorResponse{
+			Code: http.StatusNotFound,
+		})
+	})
+
+	config := func(allowedAccounts string) string {
+		return fmt.Sprintf(`
+			resource "skysql_service" default {
+				  service_type   = "transactional"
+				  topology       = "es-replica"
+				  cloud_provider = "aws"
+				  region         = "us-east-2"
+				  name           = "my-service"
+				  architecture   = "amd64"
+				  nodes          = 1
+				  size           = "sky-2x8"
+				  storage        = 100
+				  volume_type    = "io1"
+				  volume_iops    = 3000
+				  ssl_enabled    = true
+				  version        = "10.6.11-6-1"
+				  wait_for_creation = true
+				  wait_for_deletion = true
+				  wait_for_update   = true
+				  deletion_protection = false
+
+				  endpoint {
+				    name       = "primary"
+				    mechanism  = "nlb"
+				    allow_list = [{ ip = "192.168.0.1/32", comment = "office" }]
+				  }
+
+				  endpoint {
+				    name             = "private"
+				    mechanism        = "privateconnect"
+				    allowed_accounts = %s
+				  }
+			}
+	            `, allowedAccounts)
+	}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"skysql": providerserver.NewProtocol6WithError(New("")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config(`["123456789012"]`),
+				Check: resource.ComposeAggregateTestCheckFunc([]resource.TestCheckFunc{
+					resource.TestCheckResourceAttr("skysql_service.default", "id", serviceID),
+					resource.TestCheckResourceAttr("skysql_service.default", "endpoint.#", "2"),
+					resource.TestCheckResourceAttr("skysql_service.default", "endpoint.0.visibility", "public"),
+					resource.TestCheckResourceAttr("skysql_service.default", "endpoint.0.allow_list.0.ip", "192.168.0.1/32"),
+					resource.TestCheckResourceAttr("skysql_service.default", "endpoint.1.endpoint_service", endpointService),
+					resource.TestCheckNoResourceAttr("skysql_service.default", "endpoint_mechanism"),
+					resource.TestCheckResourceAttr("skysql_service.default", "ports.#", "1"),
+					resource.TestCheckResourceAttr("skysql_service.default", "ports.0.purpose", "readwrite"),
+					resource.TestCheckResourceAttr("skysql_service.default", "readwrite_port", "3306"),
+					resource.TestCheckNoResourceAttr("skysql_service.default", "readonly_port"),
+					resource.TestCheckResourceAttr("skysql_service.default", "outbound_ips.#", "2"),
+					resource.TestCheckResourceAttr("skysql_service.default", "connection_uris.%", "3"),
+					resource.TestCheckResourceAttr("skysql_service.default", "connection_uris.mysql",
+						"mysql://dbdgf42002418.sysp0000.db1.skysql.com:3306/?ssl-mode=REQUIRED"),
+					resource.TestCheckResourceAttr("skysql_service.default", "connection_uris.jdbc",
+						"jdbc:mariadb://dbdgf42002418.sysp0000.db1.skysql.com:3306/?sslMode=verify-full"),
+				}...),
+			},
+			{
+				Config: config(`["123456789012", "210987654321"]`),
+				Check: resource.ComposeAggregateTestCheckFunc([]resource.TestCheckFunc{
+					resource.TestCheckResourceAttr("skysql_service.default", "endpoint.1.allowed_accounts.#", "2"),
+					resource.TestCheckResourceAttr("skysql_service.default", "endpoint.1.endpoint_service", endpointService),
+				}...),
+			},
+		},
+	})
+}
@@ -0,0 +1,303 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	sdkresource "github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql/provisioning"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceResourceImportState(t *testing.T) {
+	tests := []struct {
+		topology string
+		// importByName imports with name:<service-name> instead of the ID
+		importByName bool
+		extraConfig  string
+		adjust       func(service *provisioning.Service)
+	}{
+		{
+			topology: "es-single",
+		},
+		{
+			topology:     "es-replica",
+			importByName: true,
+			extraConfig: `
+				  maxscale_nodes = 2
+				  maxscale_size  = "sky-2x4"
+				  tags = {
+				    environment = "test"
+				    team        = "platform"
+				  }`,
+		},
+		{
+			topology: "galera",
+			extraConfig: `
+				  nosql_enabled = true`,
+		},
+		{
+			topology: "xpand-direct",
+			extraConfig: `
+				  replication_enabled = true
+				  primary_host        = "dbpgf00000001"`,
+			adjust: func(service *provisioning.Service) {
+				service.ReplicationEnabled = true
+				service.PrimaryHost = "dbpgf00000001"
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.topology, func(t *testing.T) {
+			testServiceResourceImportState(t, tt.topology, tt.importByName, tt.extraConfig, tt.adjust)
+		})
+	}
+}
+
+func testServiceResourceImportState(t *testing.T, topology string, importByName bool, extraConfig string, adjust func(service *provisioning.Service)) {
+	const serviceID = "dbdgf42002418"
+
+	testUrl, expectRequest, close := mockSkySQLAPI(t)
+	defer close()
+	os.Setenv("TF_SKYSQL_API_KEY", "[api-key]")
+	os.Setenv("TF_SKYSQL_API_BASE_URL", testUrl)
+
+	r := require.New(t)
+
+	configureOnce.Reset()
+	var service *provisioning.Service
+
+	getService := func(w http.ResponseWriter, req *http.Request) {
+		r.Equal(
+			fmt.Sprintf("%s %s/%s", http.MethodGet, "/provisioning/v1/services", serviceID),
+			fmt.Sprintf("%s %s", req.Method, req.URL.Path))
+		w.Header().Set("Content-Type", "application/json")
+		service.Status = "ready"
+		json.NewEncoder(w).Encode(&service)
+		w.WriteHeader(http.StatusOK)
+	}
+
+	// Check API connectivity
+	expectRequest(func(w http.ResponseWriter, req *http.Request) {
+		r.Equal(http.MethodGet, req.Method)
+		r.Equal("/provisioning/v1/versions", req.URL.Path)
+		r.Equal("page_size=1", req.URL.RawQuery)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode([]provisioning.Version{})
+	})
+	// Create service
+	expectRequest(func(w http.ResponseWriter, req *http.Request) {
+		r.Equal(http.MethodPost, req.Method)
+		r.Equal("/provisioning/v1/services", req.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		payload := provisioning.CreateServiceRequest{}
+		err := json.NewDecoder(req.Body).Decode(&payload)
+		r.NoError(err)
+		service = &provisioning.Service{
+			ID:            serviceID,
+			Name:          payload.Name,
+			ProjectID:     "ed3a2e2f-2e4e-4bca-9fb0-0a6c5a8c0f84",
+			Region:        payload.Region,
+			Provider:      payload.Provider,
+			Tier:          "foundation",
+			Topology:      payload.Topology,
+			Version:       payload.Version,
+			Architecture:  payload.Architecture,
+			Size:          payload.Size,
+			Nodes:         int(payload.Nodes),
+			MaxscaleNodes: payload.MaxscaleNodes,
+			MaxscaleSize:  payload.MaxscaleSize,
+			SSLEnabled:    payload.SSLEnabled,
+			NosqlEnabled:  payload.NoSQLEnabled,
+			FQDN:          serviceID + ".sysp0000.db1.skysql.com",
+			Status:        "pending_create",
+			CreatedOn:     int(time.Now().Unix()),
+			UpdatedOn:     int(time.Now().Unix()),
+			CreatedBy:     uuid.New().String(),
+			UpdatedBy:     uuid.New().String(),
+			Endpoints: []provisioning.Endpoint{
+				{
+					Name:       "primary",
+					Mechanism:  "nlb",
+					Visibility: "public",
+					Ports: []provisioning.Port{
+						{
+							Name:    "readwrite",
+							Port:    3306,
+							Purpose: "readwrite",
+						},
+					},
+				},
+			},
+			StorageVolume: struct {
+				Size       int    `json:"size"`
+				VolumeType string `json:"volume_type"`
+				IOPS       int    `json:"iops"`
+				Throughput int    `json:"throughput"`
+			}{
+				Size:       int(payload.Storage),
+				VolumeType: payload.VolumeType,
+				IOPS:       int(payload.VolumeIOPS),
+			},
+			OutboundIps: []string{"3.136.12.34"},
+			IsActive:    true,
+			ServiceType: payload.ServiceType,
+			Tags:        payload.Tags,
+		}
+		if adjust != nil {
+			adjust(service)
+		}
+		json.NewEncoder(w).Encode(service)
+		w.WriteHeader(http.StatusCreated)
+	})
+	// Wait for creation, read the service back and refresh state
+	for i := 0; i < 3; i++ {
+		expectRequest(getService)
+	}
+	// Resolve the name of the service
+	if importByName {
+		expectRequest(func(w http.ResponseWriter, req *http.Request) {
+			r.Equal(http.MethodGet, req.Method)
+			r.Equal("/provisioning/v1/services", req.URL.Path)
+			r.Equal("name=my-service", req.URL.RawQuery)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode([]provisioning.Service{*service})
+		})
+	}
+	// Import and read the imported service
+	for i := 0; i < 2; i++ {
+		expectRequest(getService)
+	}
+	expectRequest(func(w http.ResponseWriter, req *http.Request) {
+		r.Equal(
+			fmt.Sprintf("%s %s/%s", http.MethodDelete, "/provisioning/v1/services", serviceID),
+			fmt.Sprintf("%s %s", req.Method, req.URL.Path))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+	})
+	expectRequest(func(w http.ResponseWriter, req *http.Request) {
+		r.Equal(
+			fmt.Sprintf("%s %s/%s", http.MethodGet, "/provisioning/v1/services", serviceID),
+			fmt.Sprintf("%s %s", req.Method, req.URL.Path))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(&skysql.ErrorResponse{
+			Code: http.StatusNotFound,
+		})
+	})
+
+	importStateID := ""
+	if importByName {
+		importStateID = "name:my-service"
+	}
+
+	sdkresource.Test(t, sdkresource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"skysql": providerserver.NewProtocol6WithError(New("")()),
+		},
+		Steps: []sdkresource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+			resource "skysql_service" default {
+				  project_id     = "ed3a2e2f-2e4e-4bca-9fb0-0a6c5a8c0f84"
+				  service_type   = "transactional"
+				  topology       = %q
+				  cloud_provider = "aws"
+				  region         = "us-east-2"
+				  name           = "my-service"
+				  architecture   = "amd64"
+				  nodes          = 1
+				  size           = "sky-2x8"
+				  storage        = 100
+				  volume_type    = "io1"
+				  volume_iops    = 3000
+				  ssl_enabled    = true
+				  version        = "10.6.11-6-1"
+				  deletion_protection = false
+				  %s
+			}
+	            `, topology, extraConfig),
+				Check: sdkresource.ComposeAggregateTestCheckFunc([]sdkresource.TestCheckFunc{
+					sdkresource.TestCheckResourceAttr("skysql_service.default", "id", serviceID),
+				}...),
+			},
+			{
+				ResourceName:      "skysql_service.default",
+				ImportState:       true,
+				ImportStateId:     importStateID,
+				ImportStateVerify: true,
+				// Deletion protection is a provider-side flag, import sets its default.
+				ImportStateVerifyIgnore: []string{"deletion_protection"},
+			},
+		},
+	})
+}
+
+func TestImportServiceState(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	schemaResp := &resource.SchemaResponse{}
+	(&ServiceResource{}).Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	r.False(schemaResp.Diagnostics.HasError())
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+
+	service := &provisioning.Service{
+		ID:                 "dbdgf42002418",
+		ProjectID:          "ed3a2e2f-2e4e-4bca-9fb0-0a6c5a8c0f84",
+		NosqlEnabled:       false,
+		ReplicationEnabled: true,
+		PrimaryHost:        "dbpgf00000001",
+		MaxscaleNodes:      2,
+		Tags:               map[string]string{"name": "my-service", "team": "platform"},
+		ConfigID:           "cfg-123",
+	}
+	service.StorageVolume.IOPS = 3000
+
+	diags := importServiceState(ctx, service, &state)
+	r.False(diags.HasError(), "%v", diags)
+
+	var data ServiceResourceModel
+	diags = state.Get(ctx, &data)
+	r.False(diags.HasError(), "%v", diags)
+
+	r.Equal("dbdgf42002418", data.ID.ValueString())
+	r.Equal("ed3a2e2f-2e4e-4bca-9fb0-0a6c5a8c0f84", data.ProjectID.ValueString())
+	r.True(data.WaitForCreation.ValueBool())
+	r.True(data.WaitForDeletion.ValueBool())
+	r.True(data.WaitForUpdate.ValueBool())
+	r.True(data.DeletionProtection.ValueBool())
+	r.False(data.FinalBackup.ValueBool())
+	r.True(data.NoSQLEnabled.IsNull())
+	r.True(data.ReplicationEnabled.ValueBool())
+	r.Equal("dbpgf00000001", data.PrimaryHost.ValueString())
+	r.Equal(int64(3000), data.VolumeIOPS.ValueInt64())
+	r.True(data.VolumeThroughput.IsNull())
+	r.Equal(int64(2), data.MaxscaleNodes.ValueInt64())
+	r.Equal("cfg-123", data.ConfigID.ValueString())
+
+	var tags map[string]string
+	r.False(data.Tags.ElementsAs(ctx, &tags, false).HasError())
+	r.Equal(service.Tags, tags)
+}
@@ -0,0 +1,260 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql/provisioning"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceResourceMaintenanceWindow(t *testing.T) {
+	const serviceID = "dbdgf42002418"
+
+	testUrl, expectRequest, close := mockSkySQLAPI(t)
+	defer close()
+	os.Setenv("TF_SKYSQL_API_KEY", "[api-key]")
+	os.Setenv("TF_SKYSQL_API_BASE_URL", testUrl)
+
+	r := require.New(t)
+
+	configureOnce.Reset()
+	var service *provisioning.Service
+
+	getService := func(w http.ResponseWriter, req *http.Request) {
+		r.Equal(
+			fmt.Sprintf("%s %s/%s", http.MethodGet, "/provisioning/v1/services", serviceID),
+			fmt.Sprintf("%s %s", req.Method, req.URL.Path))
+		w.Header().Set("Content-Type", "application/json")
+		service.Status = "ready"
+		json.NewEncoder(w).Encode(&service)
+		w.WriteHeader(http.StatusOK)
+	}
+
+	// Check API connectivity
+	expectRequest(func(w http.ResponseWriter, req *http.Request) {
+		r.Equal(http.MethodGet, req.Method)
+		r.Equal("/provisioning/v1/versions", req.URL.Path)
+		r.Equal("page_size=1", req.URL.RawQuery)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode([]provisioning.Version{})
+	})
+	// Create service
+	expectRequest(func(w http.ResponseWriter, req *http.Request) {
+		r.Equal(http.MethodPost, req.Method)
+		r.Equal("/provisioning/v1/services", req.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		payload := provisioning.CreateServiceRequest{}
+		err := json.NewDecoder(req.Body).Decode(&payload)
+		r.NoError(err)
+		r.Equal(&provisioning.MaintenanceWindow{
+			DayOfWeek:     "sunday",
+			StartHour:     2,
+			DurationHours: 4,
+			TimeZone:      "UTC",
+		}, payload.MaintenanceWindow)
+		service = &provisioning.Service{
+			ID:           serviceID,
+			Name:         payload.Name,
+			Region:       payload.Region,
+			Provider:     payload.Provider,
+			Tier:         "foundation",
+			Topology:     payload.Topology,
+			Version:      payload.Version,
+			Architecture: payload.Architecture,
+			Size:         payload.Size,
+			Nodes:        int(payload.Nodes),
+			SSLEnabled:   payload.SSLEnabled,
+			NosqlEnabled: payload.NoSQLEnabled,
+			Status:       "pending_create",
+			CreatedOn:    int(time.Now().Unix()),
+			UpdatedOn:    int(time.Now().Unix()),
+			CreatedBy:    uuid.New().String(),
+			UpdatedBy:    uuid.New().String(),
+			Endpoints: []provisioning.Endpoint{
+				{
+					Name:      "primary",
+					Mechanism: "nlb",
+					Ports: []provisioning.Port{
+						{
+							Name:    "readwrite",
+							Port:    3306,
+							Purpose: "readwrite",
+						},
+					},
+				},
+			},
+			StorageVolume: struct {
+				Size       int    `json:"size"`
+				VolumeType string `json:"volume_type"`
+				IOPS       int    `json:"iops"`
+				Throughput int    `json:"throughput"`
+			}{
+				Size:       int(payload.Storage),
+				VolumeType: payload.VolumeType,
+				IOPS:       int(payload.VolumeIOPS),
+			},
+			IsActive:          true,
+			ServiceType:       payload.ServiceType,
+			MaintenanceWindow: payload.MaintenanceWindow,
+		}
+		json.NewEncoder(w).Encode(service)
+		w.WriteHeader(http.StatusCreated)
+	})
+	// Wait for creation, read the service back, refresh state and refresh before the update
+	for i := 0; i < 4; i++ {
+		expectRequest(getService)
+	}
+	// Move the window in place
+	expectRequest(func(w http.ResponseWriter, req *http.Request) {
+		r.Equal(
+			fmt.Sprintf("%s %s/%s/maintenance-window", http.MethodPut, "/provisioning/v1/services", serviceID),
+			fmt.Sprintf("%s %s", req.Method, req.URL.Path))
+		w.Header().Set("Content-Type", "application/json")
+		payload := &provisioning.MaintenanceWindow{}
+		err := json.NewDecoder(req.Body).Decode(payload)
+		r.NoError(err)
+		r.Equal(&provisioning.MaintenanceWindow{
+			DayOfWeek:     "saturday",
+			StartHour:     22,
+			DurationHours: 4,
+			TimeZone:      "Europe/Berlin",
+		}, payload)
+		service.MaintenanceWindow = payload
+		w.WriteHeader(http.StatusOK)
+	})
+	// Wait for the update, read the service back and refresh state
+	for i := 0; i < 3; i++ {
+		expectRequest(getService)
+	}
+	expectRequest(func(w http.ResponseWriter, req *http.Request) {
+		r.Equal(
+			fmt.Sprintf("%s %s/%s", http.MethodDelete, "/provisioning/v1/services", serviceID),
+			fmt.Sprintf("%s %s", req.Method, req.URL.Path))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+	})
+	expectRequest(func(w http.ResponseWriter, req *http.Request) {
+		r.Equal(
+			fmt.Sprintf("%s %s/%s", http.MethodGet, "/provisioning/v1/services", serviceID),
+			fmt.Sprintf("%s %s", req.Method, req.URL.Path))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(&skysql.ErrorResponse{
+			Code: http.StatusNotFound,
+		})
+	})
+
+	config := func(window string) string {
+		return fmt.Sprintf(`
+			resource "skysql_service" default {
+				  service_type   = "transactional"
+				  topology       = "es-single"
+				  cloud_provider = "aws"
+				  region         = "us-east-2"
+				  name           = "my-service"
+				  architecture   = "amd64"
+				  nodes          = 1
+				  size           = "sky-2x8"
+				  storage        = 100
+				  volume_type    = "io1"
+				  volume_iops    = 3000
+				  ssl_enabled    = true
+				  version        = "10.6.11-6-1"
+				  deletion_protection = false
+				  maintenance_window {
+				    %s
+				  }
+			}
+	            `, window)
+	}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"skysql": providerserver.NewProtocol6WithError(New("")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config(`
+				    day_of_week    = "sunday"
+				    start_hour     = 2
+				    duration_hours = 4`),
+				Check: resource.ComposeAggregateTestCheckFunc([]resource.TestCheckFunc{
+					resource.TestCheckResourceAttr("skysql_service.default", "id", serviceID),
+					resource.TestCheckResourceAttr("skysql_service.default", "maintenance_window.day_of_week", "sunday"),
+					resource.TestCheckNoResourceAttr("skysql_service.default", "maintenance_window.time_zone"),
+				}...),
+			},
+			{
+				Config: config(`
+				    day_of_week    = "saturday"
+				    start_hour     = 22
+				    duration_hours = 4
+				    time_zone      = "Europe/Berlin"`),
+				Check: resource.ComposeAggregateTestCheckFunc([]resource.TestCheckFunc{
+					resource.TestCheckResourceAttr("skysql_service.default", "id", serviceID),
+					resource.TestCheckResourceAttr("skysql_service.default", "maintenance_window.day_of_week", "saturday"),
+					resource.TestCheckResourceAttr("skysql_service.default", "maintenance_window.start_hour", "22"),
+					resource.TestCheckResourceAttr("skysql_service.default", "maintenance_window.time_zone", "Europe/Berlin"),
+				}...),
+			},
+		},
+	})
+}
+
+func TestSetMaintenanceWindowState(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	window := func(dayOfWeek string, timeZone types.String) types.Object {
+		value, diags := types.ObjectValueFrom(ctx, maintenanceWindowAttrTypes, ServiceMaintenanceWindowModel{
+			DayOfWeek:     types.StringValue(dayOfWeek),
+			StartHour:     types.Int64Value(2),
+			DurationHours: types.Int64Value(4),
+			TimeZone:      timeZone,
+		})
+		r.False(diags.HasError(), "%v", diags)
+		return value
+	}
+	service := &provisioning.Service{
+		MaintenanceWindow: &provisioning.MaintenanceWindow{
+			DayOfWeek:     "monday",
+			StartHour:     2,
+			DurationHours: 4,
+			TimeZone:      "UTC",
+		},
+	}
+
+	// Unmanaged windows are not tracked
+	data := &ServiceResourceModel{MaintenanceWindow: types.ObjectNull(maintenanceWindowAttrTypes)}
+	r.False(setMaintenanceWindowState(ctx, data, service).HasError())
+	r.True(data.MaintenanceWindow.IsNull())
+
+	// A window moved outside of Terraform shows up, the default time zone stays unset
+	data = &ServiceResourceModel{MaintenanceWindow: window("sunday", types.StringNull())}
+	r.False(setMaintenanceWindowState(ctx, data, service).HasError())
+	r.True(data.MaintenanceWindow.Equal(window("monday", types.StringNull())))
+
+	// An explicit time zone is read back
+	data = &ServiceResourceModel{MaintenanceWindow: window("monday", types.StringValue("UTC"))}
+	r.False(setMaintenanceWindowState(ctx, data, service).HasError())
+	r.True(data.MaintenanceWindow.Equal(window("monday", types.StringValue("UTC"))))
+
+	// A window removed outside of Terraform is planned to be set again
+	data = &ServiceResourceModel{MaintenanceWindow: window("monday", types.StringNull())}
+	r.False(setMaintenanceWindowState(ctx, data, &provisioning.Service{}).HasError())
+	r.True(data.MaintenanceWindow.IsNull())
+}
@@ -0,0 +1,356 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql/provisioning"
+	"github.com/stretchr/testify/require"
+)
+
+// TestServiceResourcePartialUpdate checks that when one step of a multi-step
+// update fails, the steps that already succeeded are kept in state and are not
+// re-issued by the next apply.
+func TestServiceResourcePartialUpdate(t *testing.T) {
+	const serviceID = "dbdgf42002418"
+
+	testUrl, expectRequest, close := mockSkySQLAPI(t)
+	defer close()
+	os.Setenv("TF_SKYSQL_API_KEY", "[api-key]")
+	os.Setenv("TF_SKYSQL_API_BASE_URL", testUrl)
+
+	r := require.New(t)
+
+	configureOnce.Reset()
+	var service *provisioning.Service
+
+	getService := getPartialUpdateService(r, serviceID, &service)
+
+	// Check API connectivity
+	expectRequest(func(w http.ResponseWriter, req *http.Request) {
+		r.Equal(http.MethodGet, req.Method)
+		r.Equal("/provisioning/v1/versions", req.URL.Path)
+		r.Equal("page_size=1", req.URL.RawQuery)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode([]provisioning.Version{})
+	})
+	// Create service
+	expectRequest(createPartialUpdateService(r, serviceID, &service))
+	// Wait for creation, read the service back and refresh state
+	for i := 0; i < 3; i++ {
+		expectRequest(getService)
+	}
+	// Refresh state before the update
+	expectRequest(getService)
+	// Update service size succeeds
+	expectRequest(updatePartialUpdateSize(r, serviceID, &service))
+	// Update service nodes, submitted right after the size, fails and fails
+	// again when it is sent once the service is ready
+	expectRequest(refusePartialUpdateNodes(r, serviceID))
+	expectRequest(getService)
+	expectRequest(refusePartialUpdateNodes(r, serviceID))
+	// Refresh state before the retried update
+	expectRequest(getService)
+	// Only the nodes update is re-issued, the size is already applied
+	expectRequest(updatePartialUpdateNodes(r, serviceID, &service))
+	// Wait for update, read the service back and refresh state
+	for i := 0; i < 3; i++ {
+		expectRequest(getService)
+	}
+	// Delete the service and wait for it to be gone
+	expectRequest(deletePartialUpdateService(r, serviceID))
+	expectRequest(partialUpdateServiceDeleted(r, serviceID))
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"skysql": providerserver.NewProtocol6WithError(New("")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: partialUpdateInitialConfig,
+				Check: resource.ComposeAggregateTestCheckFunc([]resource.TestCheckFunc{
+					resource.TestCheckResourceAttr("skysql_service.default", "id", serviceID),
+				}...),
+			},
+			{
+				Config:      partialUpdateConfig,
+				ExpectError: regexp.MustCompile(`Error updating a number of nodes for the service`),
+			},
+			{
+				Config: partialUpdateConfig,
+				Check: resource.ComposeAggregateTestCheckFunc([]resource.TestCheckFunc{
+					resource.TestCheckResourceAttr("skysql_service.default", "size", "sky-4x16"),
+					resource.TestCheckResourceAttr("skysql_service.default", "nodes", "2"),
+				}...),
+			},
+		},
+	})
+}
+
+// TestServiceResourceUpdateResendsRefusedStep checks that a change the API
+// refuses while the previous change of its stage is in progress is sent again
+// once the service is ready, so a size and nodes change succeed in one apply.
+func TestServiceResourceUpdateResendsRefusedStep(t *testing.T) {
+	const serviceID = "dbdgf42002418"
+
+	testUrl, expectRequest, close := mockSkySQLAPI(t)
+	defer close()
+	os.Setenv("TF_SKYSQL_API_KEY", "[api-key]")
+	os.Setenv("TF_SKYSQL_API_BASE_URL", testUrl)
+
+	r := require.New(t)
+
+	configureOnce.Reset()
+	var service *provisioning.Service
+	getService := getPartialUpdateService(r, serviceID, &service)
+
+	// Check API connectivity
+	expectRequest(versionsResponse(t))
+	// Create service
+	expectRequest(createPartialUpdateService(r, serviceID, &service))
+	// Wait for creation, read the service back and refresh state
+	for i := 0; i < 3; i++ {
+		expectRequest(getService)
+	}
+	// Refresh state before the update
+	expectRequest(getService)
+	// The nodes update, submitted right after the size, is refused and sent
+	// again once the service is ready
+	expectRequest(updatePartialUpdateSize(r, serviceID, &service))
+	expectRequest(refusePartialUpdateNodes(r, serviceID))
+	expectRequest(getService)
+	expectRequest(updatePartialUpdateNodes(r, serviceID, &service))
+	// Wait for update, read the service back and refresh state
+	for i := 0; i < 3; i++ {
+		expectRequest(getService)
+	}
+	// Delete the service and wait for it to be gone
+	expectRequest(deletePartialUpdateService(r, serviceID))
+	expectRequest(partialUpdateServiceDeleted(r, serviceID))
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"skysql": providerserver.NewProtocol6WithError(New("")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: partialUpdateInitialConfig,
+				Check: resource.ComposeAggregateTestCheckFunc([]resource.TestCheckFunc{
+					resource.TestCheckResourceAttr("skysql_service.default", "id", serviceID),
+				}...),
+			},
+			{
+				Config: partialUpdateConfig,
+				Check: resource.ComposeAggregateTestCheckFunc([]resource.TestCheckFunc{
+					resource.TestCheckResourceAttr("skysql_service.default", "size", "sky-4x16"),
+					resource.TestCheckResourceAttr("skysql_service.default", "nodes", "2"),
+				}...),
+			},
+		},
+	})
+}
+
+const partialUpdateInitialConfig = `
+			resource "skysql_service" default {
+				  service_type   = "transactional"
+				  topology       = "es-replica"
+				  cloud_provider = "aws"
+				  region         = "us-east-2"
+				  name           = "my-service"
+				  architecture   = "amd64"
+				  nodes          = 1
+				  size           = "sky-2x8"
+				  storage        = 100
+				  volume_type    = "io1"
+				  volume_iops    = 3000
+				  ssl_enabled    = true
+				  version        = "10.6.11-6-1"
+				  wait_for_creation = true
+				  wait_for_deletion = true
+				  wait_for_update   = true
+				  deletion_protection = false
+			}
+	            `
+
+const partialUpdateConfig = `
+			resource "skysql_service" default {
+				  service_type   = "transactional"
+				  topology       = "es-replica"
+				  cloud_provider = "aws"
+				  region         = "us-east-2"
+				  name           = "my-service"
+				  architecture   = "amd64"
+				  nodes          = 2
+				  size           = "sky-4x16"
+				  storage        = 100
+				  volume_type    = "io1"
+				  volume_iops    = 3000
+				  ssl_enabled    = true
+				  version        = "10.6.11-6-1"
+				  wait_for_creation = true
+				  wait_for_deletion = true
+				  wait_for_update   = true
+				  deletion_protection = false
+			}
+	            `
+
+// createPartialUpdateService creates the service of the partial update tests.
+func createPartialUpdateService(r *require.Assertions, serviceID string, service **provisioning.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		r.Equal(http.MethodPost, req.Method)
+		r.Equal("/provisioning/v1/services", req.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		payload := provisioning.CreateServiceRequest{}
+		err := json.NewDecoder(req.Body).Decode(&payload)
+		r.NoError(err)
+		*service = &provisioning.Service{
+			ID:           serviceID,
+			Name:         payload.Name,
+			Region:       payload.Region,
+			Provider:     payload.Provider,
+			Tier:         "foundation",
+			Topology:     payload.Topology,
+			Version:      payload.Version,
+			Architecture: payload.Architecture,
+			Size:         payload.Size,
+			Nodes:        int(payload.Nodes),
+			SSLEnabled:   payload.SSLEnabled,
+			NosqlEnabled: payload.NoSQLEnabled,
+			FQDN:         "",
+			Status:       "pending_create",
+			CreatedOn:    int(time.Now().Unix()),
+			UpdatedOn:    int(time.Now().Unix()),
+			CreatedBy:    uuid.New().String(),
+			UpdatedBy:    uuid.New().String(),
+			Endpoints: []provisioning.Endpoint{
+				{
+					Name: "primary",
+					Ports: []provisioning.Port{
+						{
+							Name:    "readwrite",
+							Port:    3306,
+							Purpose: "readwrite",
+						},
+					},
+				},
+			},
+			StorageVolume: struct {
+				Size       int    `json:"size"`
+				VolumeType string `json:"volume_type"`
+				IOPS       int    `json:"iops"`
+				Throughput int    `json:"throughput"`
+			}{
+				Size:       int(payload.Storage),
+				VolumeType: payload.VolumeType,
+				IOPS:       int(payload.VolumeIOPS),
+			},
+			OutboundIps:        nil,
+			IsActive:           true,
+			ServiceType:        payload.ServiceType,
+			ReplicationEnabled: false,
+			PrimaryHost:        "",
+		}
+		json.NewEncoder(w).Encode(*service)
+		w.WriteHeader(http.StatusCreated)
+	}
+}
+
+// refusePartialUpdateNodes refuses a nodes update while the service is busy.
+func refusePartialUpdateNodes(r *require.Assertions, serviceID string) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		r.Equal(
+			fmt.Sprintf("%s %s/%s/nodes", http.MethodPost, "/provisioning/v1/services", serviceID),
+			fmt.Sprintf("%s %s", req.Method, req.URL.Path))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(&skysql.ErrorResponse{
+			Code: http.StatusBadRequest,
+			Errors: []skysql.ErrorDetails{
+				{Message: "service is busy"},
+			},
+		})
+	}
+}
+
+// updatePartialUpdateSize accepts the size update of the partial update tests.
+func updatePartialUpdateSize(r *require.Assertions, serviceID string, service **provisioning.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		r.Equal(
+			fmt.Sprintf("%s %s/%s/size", http.MethodPost, "/provisioning/v1/services", serviceID),
+			fmt.Sprintf("%s %s", req.Method, req.URL.Path))
+		w.Header().Set("Content-Type", "application/json")
+		payload := &provisioning.UpdateServiceSizeRequest{}
+		err := json.NewDecoder(req.Body).Decode(payload)
+		r.NoError(err)
+		r.Equal("sky-4x16", payload.Size)
+		(*service).Size = payload.Size
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// updatePartialUpdateNodes accepts the nodes update of the partial update tests.
+func updatePartialUpdateNodes(r *require.Assertions, serviceID string, service **provisioning.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		r.Equal(
+			fmt.Sprintf("%s %s/%s/nodes", http.MethodPost, "/provisioning/v1/services", serviceID),
+			fmt.Sprintf("%s %s", req.Method, req.URL.Path))
+		w.Header().Set("Content-Type", "application/json")
+		payload := &provisioning.UpdateServiceNodesNumberRequest{}
+		err := json.NewDecoder(req.Body).Decode(payload)
+		r.NoError(err)
+		r.Equal(int64(2), payload.Nodes)
+		(*service).Nodes = int(payload.Nodes)
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// deletePartialUpdateService accepts the deletion of the service.
+func deletePartialUpdateService(r *require.Assertions, serviceID string) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		r.Equal(
+			fmt.Sprintf("%s %s/%s", http.MethodDelete, "/provisioning/v1/services", serviceID),
+			fmt.Sprintf("%s %s", req.Method, req.URL.Path))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+// partialUpdateServiceDeleted reports the deleted service as gone.
+func partialUpdateServiceDeleted(r *require.Assertions, serviceID string) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		r.Equal(
+			fmt.Sprintf("%s %s/%s", http.MethodGet, "/provisioning/v1/services", serviceID),
+			fmt.Sprintf("%s %s", req.Method, req.URL.Path))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(&skysql.ErrorResponse{
+			Code: http.StatusNotFound,
+		})
+	}
+}
+
+// getPartialUpdateService returns the service as ready.
+func getPartialUpdateService(r *require.Assertions, serviceID string, service **provisioning.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		r.Equal(
+			fmt.Sprintf("%s %s/%s", http.MethodGet, "/provisioning/v1/services", serviceID),
+			fmt.Sprintf("%s %s", req.Method, req.URL.Path))
+		w.Header().Set("Content-Type", "application/json")
+		(*service).Status = "ready"
+		json.NewEncoder(w).Encode(*service)
+		w.WriteHeader(http.StatusOK)
+	}
+}
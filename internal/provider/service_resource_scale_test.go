@@ -119,7 +119,7 @@ func TestServiceResourceScaleTest(t *testing.T) {
 			w.WriteHeader(http.StatusOK)
 		})
 	}
-	// Update service size
+	// Update service size, nodes and storage are submitted back-to-back and share one wait
 	expectRequest(func(w http.ResponseWriter, req *http.Request) {
 		r.Equal(
 			fmt.Sprintf("%s %s/%s/size", http.MethodPost, "/provisioning/v1/services", serviceID),
@@ -131,15 +131,6 @@ func TestServiceResourceScaleTest(t *testing.T) {
 		service.Size = payload.Size
 		w.WriteHeader(http.StatusOK)
 	})
-	expectRequest(func(w http.ResponseWriter, req *http.Request) {
-		r.Equal(
-			fmt.Sprintf("%s %s/%s", http.MethodGet, "/provisioning/v1/services", serviceID),
-			fmt.Sprintf("%s %s", req.Method, req.URL.Path))
-		w.Header().Set("Content-Type", "application/json")
-		service.Status = "ready"
-		json.NewEncoder(w).Encode(&service)
-		w.WriteHeader(http.StatusOK)
-	})
 	// Update service nodes
 	expectRequest(func(w http.ResponseWriter, req *http.Request) {
 		r.Equal(
@@ -152,15 +143,7 @@ func TestServiceResourceScaleTest(t *testing.T) {
 		service.Nodes = int(payload.Nodes)
 		w.WriteHeader(http.StatusOK)
 	})
-	expectRequest(func(w http.ResponseWriter, req *http.Request) {
-		r.Equal(
-			fmt.Sprintf("%s %s/%s", http.MethodGet, "/provisioning/v1/services", serviceID),
-			fmt.Sprintf("%s %s", req.Method, req.URL.Path))
-		w.Header().Set("Content-Type", "application/json")
-		service.Status = "ready"
-		json.NewEncoder(w).Encode(&service)
-		w.WriteHeader(http.StatusOK)
-	})
+	// Update service storage, submitted together with size and nodes
 	expectRequest(func(w http.ResponseWriter, req *http.Request) {
 		r.Equal(
 			fmt.Sprintf("%s %s/%s/storage", http.MethodPatch, "/provisioning/v1/services", serviceID),
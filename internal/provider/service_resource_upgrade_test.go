@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceResourceUpgradeStateV2(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	rawState, err := json.Marshal(map[string]interface{}{
+		"id":                        "dbdgf42002418",
+		"name":                      "test-service",
+		"provider":                  "aws",
+		"endpoint_mechanism":        "privateconnect",
+		"endpoint_allowed_accounts": []string{"123456789012"},
+		"endpoint_service":          "com.amazonaws.vpce.us-east-2.vpce-svc-0123456789abcdef0",
+		"allow_list": []map[string]interface{}{
+			{"ip": "192.158.1.38/32", "comment": "homeoffice"},
+		},
+	})
+	r.NoError(err)
+
+	server := providerserver.NewProtocol6(New("test")())()
+	_, err = server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	r.NoError(err)
+	resp, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: "skysql_service",
+		Version:  2,
+		RawState: &tfprotov6.RawState{JSON: rawState},
+	})
+	r.NoError(err)
+	for _, d := range resp.Diagnostics {
+		r.Failf("unexpected diagnostic", "%s: %s", d.Summary, d.Detail)
+	}
+
+	schemaResp := &resource.SchemaResponse{}
+	(&ServiceResource{}).Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	raw, err := resp.UpgradedState.Unmarshal(schemaResp.Schema.Type().TerraformType(ctx))
+	r.NoError(err)
+
+	var data ServiceResourceModel
+	diags := (&tfsdk.State{Schema: schemaResp.Schema, Raw: raw}).Get(ctx, &data)
+	r.False(diags.HasError(), "%v", diags)
+
+	// No endpoint block is added, the flat attributes keep describing the
+	// primary endpoint
+	endpoints, diags := serviceEndpoints(ctx, &data)
+	r.False(diags.HasError(), "%v", diags)
+	r.Empty(endpoints)
+
+	r.Equal("privateconnect", data.Mechanism.ValueString())
+	r.Equal("com.amazonaws.vpce.us-east-2.vpce-svc-0123456789abcdef0", data.EndpointService.ValueString())
+	r.Len(data.AllowedAccounts.Elements(), 1)
+	var allowList []AllowListModel
+	r.False(data.AllowList.ElementsAs(ctx, &allowList, false).HasError())
+	r.Equal("192.158.1.38/32", allowList[0].IPAddress.ValueString())
+	r.Equal("homeoffice", allowList[0].Comment.ValueString())
+}
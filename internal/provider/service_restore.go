@@ -0,0 +1,187 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	sdkresource "github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql/backup"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql/provisioning"
+)
+
+// Methods to restore a new service from restore_from.
+const (
+	// restoreMethodCreate sends the source with the create request.
+	restoreMethodCreate = "create"
+	// restoreMethodRestore provisions an empty service and restores the
+	// source into it once the service is ready.
+	restoreMethodRestore = "restore"
+)
+
+// ServiceRestoreFromModel describes the restore_from attribute of the service.
+type ServiceRestoreFromModel struct {
+	BackupID        types.String `tfsdk:"backup_id"`
+	SourceServiceID types.String `tfsdk:"source_service_id"`
+	PointInTime     types.String `tfsdk:"point_in_time"`
+	Method          types.String `tfsdk:"method"`
+}
+
+var restoreFromAttrTypes = map[string]attr.Type{
+	"backup_id":         types.StringType,
+	"source_service_id": types.StringType,
+	"point_in_time":     types.StringType,
+	"method":            types.StringType,
+}
+
+// serviceRestoreFrom returns the restore_from of the service, nil when it is
+// not set.
+func serviceRestoreFrom(ctx context.Context, data *ServiceResourceModel) (*ServiceRestoreFromModel, diag.Diagnostics) {
+	if data.RestoreFrom.IsNull() || data.RestoreFrom.IsUnknown() {
+		return nil, nil
+	}
+	var restoreFrom ServiceRestoreFromModel
+	diags := data.RestoreFrom.As(ctx, &restoreFrom, basetypes.ObjectAsOptions{})
+	return &restoreFrom, diags
+}
+
+// restoreFromMethod returns the method of restore_from, create when not set.
+func restoreFromMethod(restoreFrom *ServiceRestoreFromModel) string {
+	if restoreFrom.Method.IsNull() || restoreFrom.Method.ValueString() == "" {
+		return restoreMethodCreate
+	}
+	return restoreFrom.Method.ValueString()
+}
+
+// restoreSource converts restore_from to the source sent with the create
+// request, nil when the service is restored after it was provisioned.
+func restoreSource(restoreFrom *ServiceRestoreFromModel) *provisioning.RestoreSource {
+	if restoreFrom == nil || restoreFromMethod(restoreFrom) != restoreMethodCreate {
+		return nil
+	}
+	return &provisioning.RestoreSource{
+		BackupID:        restoreFrom.BackupID.ValueString(),
+		SourceServiceID: restoreFrom.SourceServiceID.ValueString(),
+		PointInTime:     restoreFrom.PointInTime.ValueString(),
+	}
+}
+
+// validateRestoreFromPlan checks that restore_from names either a backup or a
+// source service with a point in time.
+func validateRestoreFromPlan(ctx context.Context, plan *ServiceResourceModel, resp *resource.ModifyPlanResponse) {
+	restoreFrom, diags := serviceRestoreFrom(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if restoreFrom == nil || diags.HasError() {
+		return
+	}
+	if restoreFrom.BackupID.IsUnknown() || restoreFrom.SourceServiceID.IsUnknown() || restoreFrom.PointInTime.IsUnknown() {
+		return
+	}
+
+	hasBackup := !restoreFrom.BackupID.IsNull()
+	hasSource := !restoreFrom.SourceServiceID.IsNull()
+	switch {
+	case hasBackup && hasSource:
+		resp.Diagnostics.AddAttributeError(path.Root("restore_from"),
+			"Invalid restore_from",
+			"Set either backup_id or source_service_id with point_in_time, not both.")
+	case !hasBackup && !hasSource:
+		resp.Diagnostics.AddAttributeError(path.Root("restore_from"),
+			"Invalid restore_from",
+			"Set either backup_id or source_service_id with point_in_time.")
+	case hasBackup && !restoreFrom.PointInTime.IsNull():
+		resp.Diagnostics.AddAttributeError(path.Root("restore_from").AtName("point_in_time"),
+			"Invalid restore_from",
+			"point_in_time can only be used with source_service_id.")
+	case hasSource && restoreFrom.PointInTime.IsNull():
+		resp.Diagnostics.AddAttributeError(path.Root("restore_from").AtName("point_in_time"),
+			"Invalid restore_from",
+			"point_in_time is required with source_service_id.")
+	}
+
+	if !restoreFrom.PointInTime.IsNull() {
+		if _, err := time.Parse(time.RFC3339, restoreFrom.PointInTime.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("restore_from").AtName("point_in_time"),
+				"Invalid restore_from",
+				fmt.Sprintf("point_in_time must be in RFC 3339 format: %s", err))
+		}
+	}
+
+	if !plan.WaitForCreation.IsUnknown() && !plan.WaitForCreation.ValueBool() {
+		resp.Diagnostics.AddAttributeError(path.Root("restore_from"),
+			"Invalid restore_from",
+			"restore_from requires wait_for_creation = true. The restore is tracked until it has completed.")
+	}
+}
+
+// restoreService tracks the restore of a new service. With the restore
+// method it starts the restore into the provisioned service first, with the
+// create method it waits for the restore started by the create request.
+func (r *ServiceResource) restoreService(ctx context.Context, serviceID string, restoreID string, restoreFrom *ServiceRestoreFromModel, timeout time.Duration) error {
+	if restoreFromMethod(restoreFrom) == restoreMethodRestore {
+		request := &backup.CreateRestoreRequest{
+			ServiceID:       serviceID,
+			BackupID:        restoreFrom.BackupID.ValueString(),
+			SourceServiceID: restoreFrom.SourceServiceID.ValueString(),
+		}
+		if !restoreFrom.PointInTime.IsNull() {
+			pointInTime, err := time.Parse(time.RFC3339, restoreFrom.PointInTime.ValueString())
+			if err != nil {
+				return fmt.Errorf("invalid point_in_time: %w", err)
+			}
+			request.PointInTime = &pointInTime
+		}
+		restore, err := r.client.CreateRestore(ctx, request)
+		if err != nil {
+			return fmt.Errorf("can not start restore: %w", err)
+		}
+		restoreID = restore.ID
+	}
+
+	if restoreID == "" {
+		// The service was restored as part of its creation.
+		return nil
+	}
+
+	tflog.Info(ctx, "Waiting for the service restore", map[string]interface{}{
+		"service_id": serviceID,
+		"restore_id": restoreID,
+	})
+
+	_, err := waitForRestore(ctx, r.client, restoreID, timeout)
+	return err
+}
+
+// waitForRestore waits until the restore job has succeeded or failed.
+func waitForRestore(ctx context.Context, client *skysql.Client, restoreID string, timeout time.Duration) (*backup.Restore, error) {
+	var restore *backup.Restore
+	err := sdkresource.RetryContext(ctx, timeout, func() *sdkresource.RetryError {
+		var err error
+		restore, err = client.GetRestoreByID(ctx, restoreID)
+		if err != nil {
+			return sdkresource.NonRetryableError(fmt.Errorf("error retrieving restore details: %v", err))
+		}
+
+		switch restore.Status {
+		case backup.StatusSucceeded:
+			return nil
+		case backup.StatusFailed:
+			if restore.Message != "" {
+				return sdkresource.NonRetryableError(fmt.Errorf("restore %s failed: %s", restoreID, restore.Message))
+			}
+			return sdkresource.NonRetryableError(errors.New("restore " + restoreID + " failed"))
+		}
+
+		return sdkresource.RetryableError(fmt.Errorf("expected restore to be succeeded or failed but was in state %s", restore.Status))
+	})
+	return restore, err
+}
@@ -0,0 +1,259 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql/backup"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &ServiceRestoreResource{}
+var _ resource.ResourceWithConfigure = &ServiceRestoreResource{}
+var _ resource.ResourceWithValidateConfig = &ServiceRestoreResource{}
+
+func NewServiceRestoreResource() resource.Resource {
+	return &ServiceRestoreResource{}
+}
+
+// ServiceRestoreResource defines the resource implementation.
+type ServiceRestoreResource struct {
+	client *skysql.Client
+}
+
+// ServiceRestoreResourceModel describes the resource data model.
+type ServiceRestoreResourceModel struct {
+	ID                  types.String   `tfsdk:"id"`
+	ServiceID           types.String   `tfsdk:"service_id"`
+	BackupID            types.String   `tfsdk:"backup_id"`
+	Triggers            types.Map      `tfsdk:"triggers"`
+	AcknowledgeDataLoss types.Bool     `tfsdk:"acknowledge_data_loss"`
+	Status              types.String   `tfsdk:"status"`
+	Timeouts            timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *ServiceRestoreResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service_restore"
+}
+
+func (r *ServiceRestoreResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Restores a backup into an existing service. The data of the service is replaced by the backup. " +
+			"The restore runs when the resource is created, change triggers to run it again. Destroying the resource does not undo the restore",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the restore job",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"service_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the service to restore the backup into",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"backup_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the backup to restore",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Arbitrary values that run the restore again when they change",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"acknowledge_data_loss": schema.BoolAttribute{
+				Required:    true,
+				Description: "Must be set to true to run the restore. It acknowledges that the data of the service is replaced by the backup",
+			},
+			"status": schema.StringAttribute{
+				Computed:    true,
+				Description: "The status of the restore job",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+			}),
+		},
+	}
+}
+
+// ValidateConfig rejects a restore that does not acknowledge the data loss,
+// so it fails the plan instead of the apply.
+func (r *ServiceRestoreResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var acknowledgeDataLoss types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("acknowledge_data_loss"), &acknowledgeDataLoss)...)
+	if resp.Diagnostics.HasError() || acknowledgeDataLoss.IsNull() || acknowledgeDataLoss.IsUnknown() {
+		return
+	}
+
+	if !acknowledgeDataLoss.ValueBool() {
+		resp.Diagnostics.AddAttributeError(path.Root("acknowledge_data_loss"),
+			"Can not restore service",
+			"Restoring a backup replaces the data of the service. Set acknowledge_data_loss = true to run the restore.")
+	}
+}
+
+func (r *ServiceRestoreResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*skysql.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *skysql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ServiceRestoreResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *ServiceRestoreResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// ValidateConfig rejects false already, an unknown value is checked here
+	if !data.AcknowledgeDataLoss.ValueBool() {
+		resp.Diagnostics.AddAttributeError(path.Root("acknowledge_data_loss"),
+			"Can not restore service",
+			fmt.Sprintf("Restoring backup %q replaces the data of service %q. Set acknowledge_data_loss = true to run the restore.",
+				data.BackupID.ValueString(), data.ServiceID.ValueString()))
+		return
+	}
+
+	restore, err := r.client.CreateRestore(ctx, &backup.CreateRestoreRequest{
+		ServiceID: data.ServiceID.ValueString(),
+		BackupID:  data.BackupID.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error restoring service",
+			fmt.Sprintf("Unable to start the restore of backup %q into service %q: %s", data.BackupID.ValueString(), data.ServiceID.ValueString(), err))
+		return
+	}
+
+	tflog.Info(ctx, "Waiting for the service restore", map[string]interface{}{
+		"service_id": data.ServiceID.ValueString(),
+		"restore_id": restore.ID,
+	})
+
+	// Track the restore before waiting, a failed restore taints the resource.
+	data.ID = types.StringValue(restore.ID)
+	data.Status = types.StringValue(restore.Status)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	restore, err = waitForRestore(ctx, r.client, restore.ID, createTimeout)
+	if restore != nil {
+		data.Status = types.StringValue(restore.Status)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error restoring service", fmt.Sprintf("Unable to restore service, got error: %s", err))
+		return
+	}
+}
+
+func (r *ServiceRestoreResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *ServiceRestoreResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	restore, err := r.client.GetRestoreByID(ctx, data.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, skysql.ErrorServiceNotFound) {
+			tflog.Warn(ctx, "SkySQL restore not found, removing from state", map[string]interface{}{
+				"id": data.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Can not read restore", err.Error())
+		return
+	}
+
+	data.Status = types.StringValue(restore.Status)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ServiceRestoreResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *ServiceRestoreResourceModel
+	var state *ServiceRestoreResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Everything that runs the restore requires a replacement, only the
+	// acknowledgement and the timeouts are updated in place.
+	state.AcknowledgeDataLoss = plan.AcknowledgeDataLoss
+	state.Timeouts = plan.Timeouts
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *ServiceRestoreResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *ServiceRestoreResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A restore can not be undone, the resource is only removed from state.
+	tflog.Info(ctx, "Removing the service restore from state", map[string]interface{}{
+		"id":         data.ID.ValueString(),
+		"service_id": data.ServiceID.ValueString(),
+	})
+}
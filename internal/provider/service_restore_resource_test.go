@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql/backup"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceRestoreResource(t *testing.T) {
+	const serviceID = "dbdgf42002418"
+	const backupID = "bkp-0123456789"
+
+	testUrl, expectRequest, close := mockSkySQLAPI(t)
+	defer close()
+	os.Setenv("TF_SKYSQL_API_KEY", "[api-key]")
+	os.Setenv("TF_SKYSQL_API_BASE_URL", testUrl)
+
+	r := require.New(t)
+
+	configureOnce.Reset()
+
+	startRestore := func(restoreID string) http.HandlerFunc {
+		return func(w http.ResponseWriter, req *http.Request) {
+			r.Equal(http.MethodPost, req.Method)
+			r.Equal("/skybackup/v1/restores", req.URL.Path)
+			payload := &backup.CreateRestoreRequest{}
+			err := json.NewDecoder(req.Body).Decode(payload)
+			r.NoError(err)
+			r.Equal(serviceID, payload.ServiceID)
+			r.Equal(backupID, payload.BackupID)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusAccepted)
+			json.NewEncoder(w).Encode(&backup.Restore{
+				ID:        restoreID,
+				ServiceID: serviceID,
+				BackupID:  backupID,
+				Status:    backup.StatusInProgress,
+			})
+		}
+	}
+	getRestore := func(restoreID string) http.HandlerFunc {
+		return func(w http.ResponseWriter, req *http.Request) {
+			r.Equal(http.MethodGet, req.Method)
+			r.Equal("/skybackup/v1/restores/"+restoreID, req.URL.Path)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(&backup.Restore{
+				ID:        restoreID,
+				ServiceID: serviceID,
+				BackupID:  backupID,
+				Status:    backup.StatusSucceeded,
+			})
+		}
+	}
+
+	// Check API connectivity
+	expectRequest(versionsResponse(t))
+	// Run the restore, wait for it and refresh state
+	expectRequest(startRestore("rst-1"))
+	expectRequest(getRestore("rst-1"))
+	expectRequest(getRestore("rst-1"))
+	// Refresh before the trigger change, which runs the restore again
+	expectRequest(getRestore("rst-1"))
+	expectRequest(startRestore("rst-2"))
+	expectRequest(getRestore("rst-2"))
+	expectRequest(getRestore("rst-2"))
+
+	config := func(migration string) string {
+		return fmt.Sprintf(`
+			resource "skysql_service_restore" "rollback" {
+				service_id            = %q
+				backup_id             = %q
+				acknowledge_data_loss = true
+				triggers = {
+					migration = %q
+				}
+			}`, serviceID, backupID, migration)
+	}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"skysql": providerserver.NewProtocol6WithError(New("")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config("0042"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("skysql_service_restore.rollback", "id", "rst-1"),
+					resource.TestCheckResourceAttr("skysql_service_restore.rollback", "status", backup.StatusSucceeded),
+				),
+			},
+			{
+				Config: config("0043"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("skysql_service_restore.rollback", "id", "rst-2"),
+					resource.TestCheckResourceAttr("skysql_service_restore.rollback", "status", backup.StatusSucceeded),
+				),
+			},
+		},
+	})
+}
+
+func TestServiceRestoreResource_NotAcknowledged(t *testing.T) {
+	testUrl, _, close := mockSkySQLAPI(t)
+	defer close()
+	os.Setenv("TF_SKYSQL_API_KEY", "[api-key]")
+	os.Setenv("TF_SKYSQL_API_BASE_URL", testUrl)
+
+	configureOnce.Reset()
+
+	// The config is rejected before the provider is configured, so no request
+	// is sent
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"skysql": providerserver.NewProtocol6WithError(New("")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "skysql_service_restore" "rollback" {
+					service_id            = "dbdgf42002418"
+					backup_id             = "bkp-0123456789"
+					acknowledge_data_loss = false
+				}`,
+				ExpectError: regexp.MustCompile(`Set acknowledge_data_loss = true to run the restore`),
+			},
+		},
+	})
+}
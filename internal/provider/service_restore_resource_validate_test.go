@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceRestoreValidateConfig(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	res := &ServiceRestoreResource{}
+	schemaResp := &resource.SchemaResponse{}
+	res.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	validate := func(acknowledgeDataLoss types.Bool) *resource.ValidateConfigResponse {
+		state := tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}
+		r.False(state.SetAttribute(ctx, path.Root("acknowledge_data_loss"), acknowledgeDataLoss).HasError())
+		resp := &resource.ValidateConfigResponse{}
+		res.ValidateConfig(ctx, resource.ValidateConfigRequest{
+			Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw},
+		}, resp)
+		return resp
+	}
+
+	resp := validate(types.BoolValue(false))
+	r.Equal(1, resp.Diagnostics.ErrorsCount())
+	r.Contains(resp.Diagnostics[0].Detail(), "Set acknowledge_data_loss = true to run the restore")
+
+	r.False(validate(types.BoolValue(true)).Diagnostics.HasError())
+	// A value known only at apply is checked by Create
+	r.False(validate(types.BoolUnknown()).Diagnostics.HasError())
+}
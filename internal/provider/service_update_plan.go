@@ -0,0 +1,291 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Names of the in-place changes that ServiceResource.Update can submit.
+const (
+	serviceUpdatePowerState    = "power_state"
+	serviceUpdateEndpoints     = "endpoints"
+	serviceUpdateSize          = "size"
+	serviceUpdateNodes         = "nodes"
+	serviceUpdateStorage       = "storage"
+	serviceUpdateMaxscaleNodes = "maxscale_nodes"
+	serviceUpdateMaxscaleSize  = "maxscale_size"
+	serviceUpdateSSL           = "ssl_enabled"
+	serviceUpdateAllowList     = "allow_list"
+	serviceUpdateTags          = "tags"
+	serviceUpdateConfig        = "config"
+	serviceUpdateMaintenance   = "maintenance_window"
+)
+
+// serviceUpdateDependencies is the dependency graph between in-place changes.
+// A change is only submitted after every change it depends on has been
+// submitted and the service has returned to a ready state. Changes without a
+// pending dependency are submitted back-to-back and share a single wait. A
+// change the API refuses because an earlier one of its stage is in progress is
+// sent again once the service is ready.
+//
+//		power_state ──┬──> endpoints ──────> allow_list
+//		              ├──> size ───────────┐
+//		              ├──> nodes ──────────┤
+//		              ├──> storage ────────┤
+//		              ├──> maxscale_nodes ─┼──> config
+//		              ├──> maxscale_size ──┤
+//		              └──> ssl_enabled ────┘
+//		tags
+//		maintenance_window
+//
+//	  - The service must be running before it can be reconfigured, so the power
+//	    state goes first.
+//	  - The allow list only applies to the public endpoint, so it waits for an
+//	    endpoint mechanism change to finish.
+//	  - Size, nodes, storage and the MaxScale nodes and size are independent
+//	    scaling operations and are submitted together.
+//	  - Toggling TLS restarts the listeners and is always waited on, the service
+//	    is read back right after it.
+//	  - Applying a configuration restarts the servers, so it waits for scaling and
+//	    the TLS change to finish instead of restarting a half-updated service.
+//	  - Tags and the maintenance window are metadata only and never wait for
+//	    anything.
+var serviceUpdateDependencies = map[string][]string{
+	serviceUpdatePowerState:    nil,
+	serviceUpdateEndpoints:     {serviceUpdatePowerState},
+	serviceUpdateSize:          {serviceUpdatePowerState},
+	serviceUpdateNodes:         {serviceUpdatePowerState},
+	serviceUpdateStorage:       {serviceUpdatePowerState},
+	serviceUpdateMaxscaleNodes: {serviceUpdatePowerState},
+	serviceUpdateMaxscaleSize:  {serviceUpdatePowerState},
+	serviceUpdateSSL:           {serviceUpdatePowerState},
+	serviceUpdateAllowList:     {serviceUpdateEndpoints},
+	serviceUpdateTags:          nil,
+	serviceUpdateMaintenance:   nil,
+	serviceUpdateConfig: {
+		serviceUpdateSize,
+		serviceUpdateNodes,
+		serviceUpdateStorage,
+		serviceUpdateMaxscaleNodes,
+		serviceUpdateMaxscaleSize,
+		serviceUpdateSSL,
+	},
+}
+
+// serviceUpdateStep is a single in-place change of a service.
+type serviceUpdateStep struct {
+	name string
+	// changed reports whether the plan differs from the state for this step.
+	changed func(ctx context.Context, plan *ServiceResourceModel, state *ServiceResourceModel) bool
+	// apply submits the change and reports whether the service has to be
+	// waited on before the changes depending on it can be submitted.
+	apply func(ctx context.Context, plan *ServiceResourceModel, state *ServiceResourceModel, resp *resource.UpdateResponse) bool
+	// alwaysWait makes the stage wait for the service even when
+	// wait_for_update is disabled.
+	alwaysWait bool
+}
+
+// serviceUpdateSteps returns all update steps in the order they are submitted
+// within a stage.
+func (r *ServiceResource) serviceUpdateSteps() []serviceUpdateStep {
+	return []serviceUpdateStep{
+		{name: serviceUpdatePowerState, changed: servicePowerStateChanged, apply: r.updateServicePowerState},
+		{name: serviceUpdateEndpoints, changed: serviceEndpointsChanged, apply: r.updateServiceEndpoints},
+		{name: serviceUpdateSize, changed: serviceSizeChanged, apply: r.updateServiceSize},
+		{name: serviceUpdateNodes, changed: serviceNodesChanged, apply: r.updateNumberOfNodeForService},
+		{name: serviceUpdateStorage, changed: serviceStorageChanged, apply: r.updateServiceStorage},
+		{name: serviceUpdateMaxscaleNodes, changed: serviceMaxscaleNodesChanged, apply: r.updateMaxscaleNodes},
+		{name: serviceUpdateMaxscaleSize, changed: serviceMaxscaleSizeChanged, apply: r.updateMaxscaleSize},
+		{name: serviceUpdateSSL, changed: serviceSSLChanged, apply: r.updateServiceSSL, alwaysWait: true},
+		{name: serviceUpdateAllowList, changed: serviceAllowListChanged, apply: r.updateAllowList},
+		{name: serviceUpdateTags, changed: serviceTagsChanged, apply: r.updateServiceTags},
+		{name: serviceUpdateConfig, changed: serviceConfigChanged, apply: r.updateServiceConfig},
+		{name: serviceUpdateMaintenance, changed: serviceMaintenanceWindowChanged, apply: r.updateMaintenanceWindow},
+	}
+}
+
+// planServiceUpdate groups the pending changes into stages. Every stage only
+// contains changes whose pending dependencies are in earlier stages, so the
+// changes of one stage can be submitted back-to-back followed by a single wait.
+func (r *ServiceResource) planServiceUpdate(ctx context.Context, plan *ServiceResourceModel, state *ServiceResourceModel) [][]serviceUpdateStep {
+	return groupServiceUpdateSteps(ctx, r.serviceUpdateSteps(), plan, state)
+}
+
+// applyServiceUpdateStep submits a single change of a stage. The API refuses
+// some changes while an earlier change of the same stage is still in progress,
+// e.g. the nodes right after the size. A change that fails after another one
+// was submitted is sent again once the service is ready.
+func (r *ServiceResource) applyServiceUpdateStep(ctx context.Context, step serviceUpdateStep, plan *ServiceResourceModel, state *ServiceResourceModel, resp *resource.UpdateResponse, busy bool) bool {
+	if !busy {
+		return step.apply(ctx, plan, state, resp)
+	}
+
+	attempt := &resource.UpdateResponse{State: resp.State, Private: resp.Private}
+	submitted := step.apply(ctx, plan, state, attempt)
+	removed := !resp.State.Raw.IsNull() && attempt.State.Raw.IsNull()
+	if !attempt.Diagnostics.HasError() || removed {
+		resp.State = attempt.State
+		resp.Diagnostics.Append(attempt.Diagnostics...)
+		return submitted
+	}
+
+	tflog.Info(ctx, "Service refused the change, sending it again once the service is ready", map[string]interface{}{
+		"id":   state.ID.ValueString(),
+		"step": step.name,
+	})
+	r.waitForServiceReady(ctx, state, resp)
+	if resp.Diagnostics.HasError() {
+		return false
+	}
+	return step.apply(ctx, plan, state, resp)
+}
+
+func groupServiceUpdateSteps(ctx context.Context, steps []serviceUpdateStep, plan *ServiceResourceModel, state *ServiceResourceModel) [][]serviceUpdateStep {
+	pending := make(map[string]bool, len(steps))
+	for _, step := range steps {
+		pending[step.name] = step.changed(ctx, plan, state)
+	}
+
+	levels := make(map[string]int, len(steps))
+	var level func(name string) int
+	level = func(name string) int {
+		if l, ok := levels[name]; ok {
+			return l
+		}
+		l := 0
+		for _, dep := range serviceUpdateDependencies[name] {
+			depLevel := level(dep)
+			if pending[dep] {
+				depLevel++
+			}
+			if depLevel > l {
+				l = depLevel
+			}
+		}
+		levels[name] = l
+		return l
+	}
+
+	var stages [][]serviceUpdateStep
+	for _, step := range steps {
+		if !pending[step.name] {
+			continue
+		}
+		l := level(step.name)
+		for len(stages) <= l {
+			stages = append(stages, nil)
+		}
+		stages[l] = append(stages[l], step)
+	}
+
+	// Drop the stages that are left empty because only some changes are pending.
+	result := make([][]serviceUpdateStep, 0, len(stages))
+	for _, stage := range stages {
+		if len(stage) > 0 {
+			result = append(result, stage)
+		}
+	}
+	return result
+}
+
+func serviceSSLChanged(_ context.Context, plan *ServiceResourceModel, state *ServiceResourceModel) bool {
+	return !plan.SSLEnabled.IsUnknown() && plan.SSLEnabled.ValueBool() != state.SSLEnabled.ValueBool()
+}
+
+func servicePowerStateChanged(_ context.Context, plan *ServiceResourceModel, state *ServiceResourceModel) bool {
+	return !plan.IsActive.IsUnknown() && plan.IsActive.ValueBool() != state.IsActive.ValueBool()
+}
+
+func serviceEndpointsChanged(ctx context.Context, plan *ServiceResourceModel, state *ServiceResourceModel) bool {
+	if hasEndpointBlocks(plan) {
+		return serviceEndpointBlocksChanged(ctx, plan, state)
+	}
+	if plan.Mechanism.ValueString() != state.Mechanism.ValueString() {
+		return true
+	}
+
+	var planAllowedAccounts []string
+	var stateAllowedAccounts []string
+	if plan.AllowedAccounts.ElementsAs(ctx, &planAllowedAccounts, false).HasError() ||
+		state.AllowedAccounts.ElementsAs(ctx, &stateAllowedAccounts, false).HasError() {
+		// Let the update step report the conversion error.
+		return true
+	}
+	return !reflect.DeepEqual(planAllowedAccounts, stateAllowedAccounts)
+}
+
+func serviceSizeChanged(_ context.Context, plan *ServiceResourceModel, state *ServiceResourceModel) bool {
+	return plan.Size.ValueString() != state.Size.ValueString()
+}
+
+func serviceNodesChanged(_ context.Context, plan *ServiceResourceModel, state *ServiceResourceModel) bool {
+	return plan.Nodes.ValueInt64() != state.Nodes.ValueInt64()
+}
+
+func serviceStorageChanged(_ context.Context, plan *ServiceResourceModel, state *ServiceResourceModel) bool {
+	return plan.Storage.ValueInt64() != state.Storage.ValueInt64() ||
+		plan.VolumeIOPS.ValueInt64() != state.VolumeIOPS.ValueInt64() ||
+		plan.VolumeThroughput.ValueInt64() != state.VolumeThroughput.ValueInt64()
+}
+
+func serviceMaxscaleNodesChanged(_ context.Context, plan *ServiceResourceModel, state *ServiceResourceModel) bool {
+	if plan.MaxscaleNodes.IsNull() || plan.MaxscaleNodes.IsUnknown() {
+		return false
+	}
+	return plan.MaxscaleNodes.ValueInt64() != state.MaxscaleNodes.ValueInt64()
+}
+
+func serviceMaxscaleSizeChanged(_ context.Context, plan *ServiceResourceModel, state *ServiceResourceModel) bool {
+	if plan.MaxscaleSize.IsNull() || plan.MaxscaleSize.IsUnknown() {
+		return false
+	}
+	return plan.MaxscaleSize.ValueString() != state.MaxscaleSize.ValueString()
+}
+
+func serviceAllowListChanged(ctx context.Context, plan *ServiceResourceModel, state *ServiceResourceModel) bool {
+	// Endpoint blocks carry their own allow lists, which are part of the endpoints step.
+	if hasEndpointBlocks(plan) || plan.AllowList.IsUnknown() || plan.IgnoreAllowList.ValueBool() {
+		return false
+	}
+
+	var planAllowList []AllowListModel
+	var stateAllowList []AllowListModel
+	if plan.AllowList.ElementsAs(ctx, &planAllowList, false).HasError() ||
+		state.AllowList.ElementsAs(ctx, &stateAllowList, false).HasError() {
+		// Let the update step report the conversion error.
+		return true
+	}
+	if !reflect.DeepEqual(planAllowList, stateAllowList) {
+		return true
+	}
+	// Entries that expired since the last apply are removed from the service.
+	return !plan.ExpiredAllowList.IsUnknown() &&
+		!listElementsEqual[AllowListModel](ctx, plan.ExpiredAllowList, state.ExpiredAllowList)
+}
+
+func serviceTagsChanged(ctx context.Context, plan *ServiceResourceModel, state *ServiceResourceModel) bool {
+	// Removing tags from the configuration only stops managing them.
+	if plan.Tags.IsNull() || plan.Tags.IsUnknown() {
+		return !plan.Tags.Equal(state.Tags)
+	}
+
+	var planTags map[string]string
+	if plan.Tags.ElementsAs(ctx, &planTags, false).HasError() {
+		return true
+	}
+
+	var stateTags map[string]string
+	if !state.Tags.IsNull() && !state.Tags.IsUnknown() {
+		if state.Tags.ElementsAs(ctx, &stateTags, false).HasError() {
+			stateTags = make(map[string]string)
+		}
+	}
+	return !reflect.DeepEqual(planTags, stateTags)
+}
+
+func serviceConfigChanged(_ context.Context, plan *ServiceResourceModel, state *ServiceResourceModel) bool {
+	return plan.ConfigID.ValueString() != state.ConfigID.ValueString()
+}
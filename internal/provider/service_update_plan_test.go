@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql/provisioning"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupServiceUpdateSteps(t *testing.T) {
+	names := []string{
+		serviceUpdatePowerState,
+		serviceUpdateEndpoints,
+		serviceUpdateSize,
+		serviceUpdateNodes,
+		serviceUpdateStorage,
+		serviceUpdateMaxscaleNodes,
+		serviceUpdateMaxscaleSize,
+		serviceUpdateSSL,
+		serviceUpdateAllowList,
+		serviceUpdateTags,
+		serviceUpdateConfig,
+		serviceUpdateMaintenance,
+	}
+
+	stagesOf := func(pending ...string) [][]string {
+		changed := make(map[string]bool, len(pending))
+		for _, name := range pending {
+			changed[name] = true
+		}
+		steps := make([]serviceUpdateStep, 0, len(names))
+		for _, name := range names {
+			name := name
+			steps = append(steps, serviceUpdateStep{
+				name: name,
+				changed: func(context.Context, *ServiceResourceModel, *ServiceResourceModel) bool {
+					return changed[name]
+				},
+			})
+		}
+
+		var result [][]string
+		for _, stage := range groupServiceUpdateSteps(context.Background(), steps, nil, nil) {
+			var stageNames []string
+			for _, step := range stage {
+				stageNames = append(stageNames, step.name)
+			}
+			result = append(result, stageNames)
+		}
+		return result
+	}
+
+	r := require.New(t)
+
+	r.Empty(stagesOf())
+
+	r.Equal([][]string{
+		{serviceUpdateSize, serviceUpdateNodes, serviceUpdateStorage},
+	}, stagesOf(serviceUpdateSize, serviceUpdateNodes, serviceUpdateStorage))
+
+	r.Equal([][]string{
+		{serviceUpdateNodes, serviceUpdateMaxscaleNodes},
+		{serviceUpdateConfig},
+	}, stagesOf(serviceUpdateNodes, serviceUpdateMaxscaleNodes, serviceUpdateConfig))
+
+	r.Equal([][]string{
+		{serviceUpdateEndpoints, serviceUpdateSize, serviceUpdateTags},
+		{serviceUpdateAllowList, serviceUpdateConfig},
+	}, stagesOf(serviceUpdateEndpoints, serviceUpdateSize, serviceUpdateAllowList, serviceUpdateTags, serviceUpdateConfig))
+
+	r.Equal([][]string{
+		{serviceUpdatePowerState, serviceUpdateTags},
+		{serviceUpdateNodes},
+		{serviceUpdateConfig},
+	}, stagesOf(serviceUpdatePowerState, serviceUpdateNodes, serviceUpdateTags, serviceUpdateConfig))
+
+	r.Equal([][]string{
+		{serviceUpdateSSL, serviceUpdateTags},
+		{serviceUpdateConfig},
+	}, stagesOf(serviceUpdateSSL, serviceUpdateTags, serviceUpdateConfig))
+
+	r.Equal([][]string{
+		{serviceUpdatePowerState, serviceUpdateMaintenance},
+		{serviceUpdateSize},
+	}, stagesOf(serviceUpdatePowerState, serviceUpdateSize, serviceUpdateMaintenance))
+
+	// A change whose dependencies are not pending is not held back.
+	r.Equal([][]string{
+		{serviceUpdateAllowList, serviceUpdateConfig},
+	}, stagesOf(serviceUpdateAllowList, serviceUpdateConfig))
+}
+
+func TestApplyServiceUpdateStepResendsRefusedChange(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	var reads int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		reads++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(provisioning.Service{ID: "service-a", Status: "ready"})
+	}))
+	defer srv.Close()
+
+	res := &ServiceResource{client: skysql.New(srv.URL, "[api-key]", "")}
+	state := &ServiceResourceModel{ID: types.StringValue("service-a")}
+
+	var attempts int
+	refusedOnce := serviceUpdateStep{
+		name: serviceUpdateNodes,
+		apply: func(_ context.Context, _ *ServiceResourceModel, _ *ServiceResourceModel, resp *resource.UpdateResponse) bool {
+			attempts++
+			if attempts == 1 {
+				resp.Diagnostics.AddError("Error updating a number of nodes for the service", "service is busy")
+				return false
+			}
+			return true
+		},
+	}
+
+	// The first change of a stage is not sent again
+	resp := &resource.UpdateResponse{}
+	r.False(res.applyServiceUpdateStep(ctx, refusedOnce, state, state, resp, false))
+	r.True(resp.Diagnostics.HasError())
+	r.Zero(reads)
+
+	// A change after another one waits for the service and is sent again
+	attempts = 0
+	resp = &resource.UpdateResponse{}
+	r.True(res.applyServiceUpdateStep(ctx, refusedOnce, state, state, resp, true))
+	r.False(resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+	r.Equal(2, attempts)
+	r.Equal(1, reads)
+}
@@ -2,21 +2,25 @@ package provider
 
 import (
 	"context"
-	"github.com/asaskevich/govalidator"
+	"fmt"
+	"net/netip"
+	"strings"
+
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
-	"net"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql/provisioning"
 )
 
 type allowListIPValidator struct{}
 
 // Description returns a plain text description of the validator's behavior, suitable for a practitioner to understand its impact.
 func (v allowListIPValidator) Description(ctx context.Context) string {
-	return "IP address must be in a CIDR IP address format, that looks like a normal IP address except that it ends with a slash followed by a number, called the IP network prefix."
+	return "IP address must be an IPv4 or IPv6 address or a CIDR range, that looks like a normal IP address except that it ends with a slash followed by a number, called the IP network prefix."
 }
 
 // MarkdownDescription returns a markdown formatted description of the validator's behavior, suitable for a practitioner to understand its impact.
 func (v allowListIPValidator) MarkdownDescription(ctx context.Context) string {
-	return "IP address must be in a CIDR IP address format, that looks like a normal IP address except that it ends with a slash followed by a number, called the IP network prefix."
+	return "IP address must be an IPv4 or IPv6 address or a CIDR range, that looks like a normal IP address except that it ends with a slash followed by a number, called the IP network prefix."
 }
 
 // ValidateString Validate runs the main validation logic of the validator, reading configuration data out of `req` and updating `resp` with diagnostics.
@@ -26,33 +30,167 @@ func (v allowListIPValidator) ValidateString(ctx context.Context, req validator.
 		return
 	}
 
-	ip := req.ConfigValue.ValueString()
-	if !govalidator.IsCIDR(ip) && govalidator.IsIP(ip) {
-		ip += "/32"
-	}
-	if !isValidCIDR(ip) {
+	if _, err := canonicalCIDR(req.ConfigValue.ValueString()); err != nil {
 		resp.Diagnostics.AddAttributeError(
 			req.Path,
 			"Incorrect IP address format",
-			"IP address must be in a CIDR IP address format, that looks like a normal IP address except that it ends with a slash followed by a number, called the IP network prefix.",
+			fmt.Sprintf("IP address must be an IPv4 or IPv6 address or a CIDR range, e.g. 192.0.2.1, 192.0.2.0/24 or 2001:db8::/32: %s.", err),
 		)
 	}
 }
 
-func isValidCIDR(cidr string) bool {
-	_, ipnet, err := net.ParseCIDR(cidr)
+// canonicalCIDR returns the canonical form of an allow list address. A bare
+// IP address is a single-address range, /32 for IPv4 and /128 for IPv6. A
+// range with host bits set, e.g. 10.0.0.5/24, is rejected with the network it
+// belongs to.
+func canonicalCIDR(ip string) (string, error) {
+	if !strings.Contains(ip, "/") {
+		addr, err := netip.ParseAddr(ip)
+		if err != nil || addr.Zone() != "" {
+			return "", fmt.Errorf("%q is neither an IP address nor a CIDR range", ip)
+		}
+		return netip.PrefixFrom(addr, addr.BitLen()).String(), nil
+	}
+
+	prefix, err := netip.ParsePrefix(ip)
 	if err != nil {
-		// input is not a valid CIDR notation
-		return false
+		return "", fmt.Errorf("%q is not a valid CIDR range", ip)
+	}
+	if masked := prefix.Masked(); masked != prefix {
+		return "", fmt.Errorf("%q has host bits set, the range starts at %s", ip, masked)
+	}
+	return prefix.String(), nil
+}
+
+// allowListOverlap is a pair of allow list entries where the range of Inner
+// is the same as or within the range of Outer.
+type allowListOverlap struct {
+	Outer int
+	Inner int
+}
+
+// allowListOverlaps returns the entries that are duplicates of or contained
+// in an earlier or wider entry. Invalid addresses are ignored.
+func allowListOverlaps(ips []string) []allowListOverlap {
+	prefixes := make([]netip.Prefix, len(ips))
+	for i, ip := range ips {
+		prefixes[i], _ = allowListPrefix(ip)
+	}
+
+	var overlaps []allowListOverlap
+	for inner := range prefixes {
+		for outer := range prefixes {
+			if inner == outer || !prefixContains(prefixes[outer], prefixes[inner]) {
+				continue
+			}
+			// Of two identical ranges the later one is the duplicate.
+			if prefixes[outer] == prefixes[inner] && outer > inner {
+				continue
+			}
+			overlaps = append(overlaps, allowListOverlap{Outer: outer, Inner: inner})
+			break
+		}
 	}
+	return overlaps
+}
 
-	if cidr == ipnet.String() {
-		return true
+// allowListPrefix parses an allow list address into its canonical range.
+func allowListPrefix(ip string) (netip.Prefix, bool) {
+	canonical, err := canonicalCIDR(ip)
+	if err != nil {
+		return netip.Prefix{}, false
 	}
+	return netip.MustParsePrefix(canonical), true
+}
+
+// prefixContains returns whether the range inner is the same as or within
+// the range outer.
+func prefixContains(outer netip.Prefix, inner netip.Prefix) bool {
+	return outer.IsValid() && inner.IsValid() && outer.Bits() <= inner.Bits() && outer.Contains(inner.Addr())
+}
 
+// collapsedAllowListEntries returns the indexes of the entries that are
+// duplicates of or contained in another entry.
+func collapsedAllowListEntries(ips []string) map[int]bool {
+	collapsed := make(map[int]bool)
+	for _, overlap := range allowListOverlaps(ips) {
+		collapsed[overlap.Inner] = true
+	}
+	return collapsed
+}
+
+// allowListCovered returns whether an entry of the allow list covers ip.
+func allowListCovered(allowList []provisioning.AllowListItem, ip string) bool {
+	inner, ok := allowListPrefix(ip)
+	if !ok {
+		return false
+	}
+	for i := range allowList {
+		if outer, ok := allowListPrefix(allowList[i].IPAddress); ok && prefixContains(outer, inner) {
+			return true
+		}
+	}
 	return false
 }
 
+// allowListOverlapValidator warns about allow list entries that duplicate or
+// are contained in another entry. The warnings are skipped when the boolean
+// sibling attribute named by aggregate is true, the entries are collapsed
+// then.
+type allowListOverlapValidator struct {
+	aggregate string
+}
+
+// Description returns a plain text description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (v allowListOverlapValidator) Description(ctx context.Context) string {
+	return "allow list entries should not duplicate or overlap each other"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (v allowListOverlapValidator) MarkdownDescription(ctx context.Context) string {
+	return "allow list entries should not duplicate or overlap each other"
+}
+
+// ValidateList Validate runs the main validation logic of the validator, reading configuration data out of `req` and updating `resp` with diagnostics.
+func (v allowListOverlapValidator) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	if v.aggregate != "" {
+		var aggregate types.Bool
+		diags := req.Config.GetAttribute(ctx, req.Path.ParentPath().AtName(v.aggregate), &aggregate)
+		if !diags.HasError() && aggregate.ValueBool() {
+			return
+		}
+	}
+
+	var entries []AllowListModel
+	if diags := req.ConfigValue.ElementsAs(ctx, &entries, false); diags.HasError() {
+		return
+	}
+	ips := make([]string, len(entries))
+	for i := range entries {
+		if entries[i].IPAddress.IsUnknown() || entries[i].IPAddress.IsNull() {
+			continue
+		}
+		ips[i] = entries[i].IPAddress.ValueString()
+	}
+
+	for _, overlap := range allowListOverlaps(ips) {
+		outer, inner := ips[overlap.Outer], ips[overlap.Inner]
+		if sameAllowListIP(outer, inner) {
+			resp.Diagnostics.AddAttributeWarning(req.Path.AtListIndex(overlap.Inner),
+				"Duplicate allow list entry",
+				fmt.Sprintf("%s is already allowed by entry %d (%s). The duplicate entry has no effect.", inner, overlap.Outer, outer))
+			continue
+		}
+		resp.Diagnostics.AddAttributeWarning(req.Path.AtListIndex(overlap.Inner),
+			"Overlapping allow list entry",
+			fmt.Sprintf("%s is contained in entry %d (%s). The narrower entry has no effect.", inner, overlap.Outer, outer))
+	}
+}
+
 // Contains checks if slice contains a value
 func Contains[T comparable](slice []T, value T) bool {
 	for _, a := range slice {
@@ -38,10 +38,10 @@ type AutoScaleAction struct {
 	Params  json.RawMessage `json:"params"`
 }
 
-func NewAutoScaleDiskAction(maxStorageSizeGBs int64) AutoScaleAction {
+func NewAutoScaleDiskAction(maxStorageSizeGBs int64, enabled bool) AutoScaleAction {
 	action := AutoScaleAction{
 		Group:   AutoScaleDiskActionGroup,
-		Enabled: true,
+		Enabled: enabled,
 	}
 	rawMessage, err := json.Marshal(&AutoScaleDiskActionParams{
 		MaxStorageSizeGBs: maxStorageSizeGBs,
@@ -56,10 +56,10 @@ func NewAutoScaleDiskAction(maxStorageSizeGBs int64) AutoScaleAction {
 	return action
 }
 
-func NewAutoScaleNodesHorizontalAction(minNodes int64, maxNodes int64) AutoScaleAction {
+func NewAutoScaleNodesHorizontalAction(minNodes int64, maxNodes int64, enabled bool) AutoScaleAction {
 	action := AutoScaleAction{
 		Group:   AutoScaleNodesHorizontalActionGroup,
-		Enabled: true,
+		Enabled: enabled,
 	}
 	rawMessage, err := json.Marshal(&AutoScaleNodesHorizontalActionParams{
 		MinNodes: minNodes,
@@ -75,10 +75,10 @@ func NewAutoScaleNodesHorizontalAction(minNodes int64, maxNodes int64) AutoScale
 	return action
 }
 
-func NewAutoScaleNodesVerticalAction(minNodeSize string, maxNodeSize string) AutoScaleAction {
+func NewAutoScaleNodesVerticalAction(minNodeSize string, maxNodeSize string, enabled bool) AutoScaleAction {
 	action := AutoScaleAction{
 		Group:   AutoScaleNodesVerticalActionGroup,
-		Enabled: true,
+		Enabled: enabled,
 	}
 	rawMessage, err := json.Marshal(&AutoScaleNodesVerticalActionParams{
 		MaxNodeSize: minNodeSize,
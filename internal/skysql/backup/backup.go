@@ -0,0 +1,47 @@
+package backup
+
+import "time"
+
+const (
+	TypeFull        = "full"
+	TypeIncremental = "incremental"
+	TypeBinlog      = "binlog"
+)
+
+const (
+	StatusInProgress = "in_progress"
+	StatusSucceeded  = "succeeded"
+	StatusFailed     = "failed"
+)
+
+// Backup is a backup of a service.
+type Backup struct {
+	ID        string `json:"id"`
+	ServiceID string `json:"service_id"`
+	Name      string `json:"name,omitempty"`
+	Type      string `json:"backup_type"`
+	Status    string `json:"status"`
+	// Size is the size of the backup in bytes.
+	Size      int64      `json:"size"`
+	StartTime *time.Time `json:"start_time,omitempty"`
+	EndTime   *time.Time `json:"end_time,omitempty"`
+	// PointInTimeStart and PointInTimeEnd bound the window the backup can
+	// restore a service to, they are only set for binlog backups.
+	PointInTimeStart *time.Time `json:"point_in_time_start,omitempty"`
+	PointInTimeEnd   *time.Time `json:"point_in_time_end,omitempty"`
+}
+
+// List is a page of backups returned by GET /skybackup/v1/backups.
+// NextPageToken is empty on the last page.
+type List struct {
+	Backups       []Backup `json:"backups"`
+	NextPageToken string   `json:"next_page_token,omitempty"`
+}
+
+// CreateBackupRequest is the request body for POST /skybackup/v1/backups,
+// it starts an on-demand backup of a service.
+type CreateBackupRequest struct {
+	ServiceID string `json:"service_id"`
+	Type      string `json:"backup_type"`
+	Name      string `json:"name,omitempty"`
+}
@@ -0,0 +1,24 @@
+package backup
+
+import "time"
+
+// Restore is a restore job that loads a backup, or the state of another
+// service at a point in time, into a service.
+type Restore struct {
+	ID              string     `json:"id"`
+	ServiceID       string     `json:"service_id"`
+	BackupID        string     `json:"backup_id,omitempty"`
+	SourceServiceID string     `json:"source_service_id,omitempty"`
+	PointInTime     *time.Time `json:"point_in_time,omitempty"`
+	Status          string     `json:"status"`
+	Message         string     `json:"message,omitempty"`
+}
+
+// CreateRestoreRequest is the request body for POST /skybackup/v1/restores.
+// Either BackupID or SourceServiceID together with PointInTime is set.
+type CreateRestoreRequest struct {
+	ServiceID       string     `json:"service_id"`
+	BackupID        string     `json:"backup_id,omitempty"`
+	SourceServiceID string     `json:"source_service_id,omitempty"`
+	PointInTime     *time.Time `json:"point_in_time,omitempty"`
+}
@@ -0,0 +1,35 @@
+package backup
+
+// Schedule is a recurring backup of a service.
+type Schedule struct {
+	ID              string           `json:"id"`
+	ServiceID       string           `json:"service_id"`
+	Type            string           `json:"backup_type"`
+	Schedule        string           `json:"schedule"`
+	RetentionDays   int64            `json:"retention_days"`
+	ExternalStorage *ExternalStorage `json:"external_storage,omitempty"`
+}
+
+// ExternalStorage is a bucket outside of SkySQL the backups are written to.
+// The credentials are never returned by the API.
+type ExternalStorage struct {
+	BucketPath  string `json:"bucket_path"`
+	Credentials string `json:"credentials,omitempty"`
+}
+
+// CreateScheduleRequest is the request body for POST /skybackup/v1/backups/schedules.
+type CreateScheduleRequest struct {
+	ServiceID       string           `json:"service_id"`
+	Type            string           `json:"backup_type"`
+	Schedule        string           `json:"schedule"`
+	RetentionDays   int64            `json:"retention_days,omitempty"`
+	ExternalStorage *ExternalStorage `json:"external_storage,omitempty"`
+}
+
+// UpdateScheduleRequest is the request body for PATCH /skybackup/v1/backups/schedules/{id}.
+// A nil ExternalStorage removes the external storage target.
+type UpdateScheduleRequest struct {
+	Schedule        string           `json:"schedule"`
+	RetentionDays   int64            `json:"retention_days,omitempty"`
+	ExternalStorage *ExternalStorage `json:"external_storage"`
+}
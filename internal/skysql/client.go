@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 
 	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql/autonomous"
+	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql/backup"
 	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql/organization"
 	"github.com/skysqlinc/terraform-provider-skysql/internal/skysql/provisioning"
 )
@@ -96,6 +97,42 @@ func WithPageSize(value uint) func(url.Values) {
 	}
 }
 
+func WithName(value string) func(url.Values) {
+	return func(values url.Values) {
+		values.Set("name", value)
+	}
+}
+
+func WithProjectID(value string) func(url.Values) {
+	return func(values url.Values) {
+		values.Set("project_id", value)
+	}
+}
+
+func WithStatus(value string) func(url.Values) {
+	return func(values url.Values) {
+		values.Set("status", value)
+	}
+}
+
+func WithBackupType(value string) func(url.Values) {
+	return func(values url.Values) {
+		values.Set("backup_type", value)
+	}
+}
+
+func WithStartedAfter(value time.Time) func(url.Values) {
+	return func(values url.Values) {
+		values.Set("started_after", value.UTC().Format(time.RFC3339))
+	}
+}
+
+func WithStartedBefore(value time.Time) func(url.Values) {
+	return func(values url.Values) {
+		values.Set("started_before", value.UTC().Format(time.RFC3339))
+	}
+}
+
 func (c *Client) GetVersions(ctx context.Context, options ...func(url.Values)) ([]provisioning.Version, error) {
 	request := c.HTTPClient.R()
 	for _, option := range options {
@@ -117,6 +154,58 @@ func (c *Client) GetVersions(ctx context.Context, options ...func(url.Values)) (
 	return *resp.Result().(*[]provisioning.Version), err
 }
 
+func WithProvider(value string) func(url.Values) {
+	return func(values url.Values) {
+		values.Set("provider", value)
+	}
+}
+
+func WithArchitecture(value string) func(url.Values) {
+	return func(values url.Values) {
+		values.Set("architecture", value)
+	}
+}
+
+func (c *Client) GetNodeSizes(ctx context.Context, options ...func(url.Values)) ([]provisioning.NodeSize, error) {
+	request := c.HTTPClient.R()
+	for _, option := range options {
+		option(request.QueryParam)
+	}
+	resp, err := request.
+		SetHeader("Accept", "application/json").
+		SetResult([]provisioning.NodeSize{}).
+		SetError(&ErrorResponse{}).
+		SetContext(ctx).
+		Get("/provisioning/v1/sizes")
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return nil, handleError(resp)
+	}
+	return *resp.Result().(*[]provisioning.NodeSize), err
+}
+
+func (c *Client) GetServices(ctx context.Context, options ...func(url.Values)) ([]provisioning.Service, error) {
+	request := c.HTTPClient.R()
+	for _, option := range options {
+		option(request.QueryParam)
+	}
+	resp, err := request.
+		SetHeader("Accept", "application/json").
+		SetResult([]provisioning.Service{}).
+		SetError(&ErrorResponse{}).
+		SetContext(ctx).
+		Get("/provisioning/v1/services")
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return nil, handleError(resp)
+	}
+	return *resp.Result().(*[]provisioning.Service), err
+}
+
 func (c *Client) GetServiceByID(ctx context.Context, serviceID string) (*provisioning.Service, error) {
 	resp, err := c.HTTPClient.R().
 		SetHeader("Accept", "application/json").
@@ -231,6 +320,12 @@ func handleError(resp *resty.Response) error {
 	if resp.StatusCode() == 401 {
 		return ErrorUnauthorized
 	}
+	if resp.StatusCode() == http.StatusConflict || resp.StatusCode() == http.StatusPreconditionFailed {
+		if errResp, ok := resp.Error().(*ErrorResponse); ok && len(errResp.Errors) > 0 {
+			return fmt.Errorf("%w: %s", ErrorConflict, errResp.Errors[0].Message)
+		}
+		return ErrorConflict
+	}
 	if resp.Error() != nil {
 		errResp, ok := resp.Error().(*ErrorResponse)
 		if ok && len(errResp.Errors) > 0 {
@@ -257,6 +352,40 @@ func (c *Client) SetServicePowerState(ctx context.Context, serviceID string, isA
 	return err
 }
 
+func (c *Client) SetServiceSSL(ctx context.Context, serviceID string, sslEnabled bool) error {
+	resp, err := c.HTTPClient.R().
+		SetHeader("Accept", "application/json").
+		SetContext(ctx).
+		SetBody(&provisioning.SSLRequest{SSLEnabled: sslEnabled}).
+		SetError(&ErrorResponse{}).
+		Post("/provisioning/v1/services/" + serviceID + "/security/ssl")
+	if err != nil {
+		return err
+	}
+	if resp.IsError() {
+		return handleError(resp)
+	}
+
+	return err
+}
+
+func (c *Client) SetServiceMaintenanceWindow(ctx context.Context, serviceID string, window *provisioning.MaintenanceWindow) error {
+	resp, err := c.HTTPClient.R().
+		SetHeader("Accept", "application/json").
+		SetContext(ctx).
+		SetBody(window).
+		SetError(&ErrorResponse{}).
+		Put("/provisioning/v1/services/" + serviceID + "/maintenance-window")
+	if err != nil {
+		return err
+	}
+	if resp.IsError() {
+		return handleError(resp)
+	}
+
+	return err
+}
+
 func (c *Client) ModifyServiceEndpoints(
 	ctx context.Context,
 	serviceID string,
@@ -264,14 +393,27 @@ func (c *Client) ModifyServiceEndpoints(
 	allowedAccounts []string,
 	visibility string,
 ) (*provisioning.ServiceEndpoint, error) {
+	response, err := c.PatchServiceEndpoints(ctx, serviceID, provisioning.PatchServiceEndpointsRequest{
+		{Mechanism: mechanism,
+			AllowedAccounts: allowedAccounts,
+			Visibility:      visibility},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &response[0], err
+}
+
+// PatchServiceEndpoints modifies several named endpoints of a service in one request.
+func (c *Client) PatchServiceEndpoints(
+	ctx context.Context,
+	serviceID string,
+	endpoints provisioning.PatchServiceEndpointsRequest,
+) (provisioning.PatchServiceEndpointsResponse, error) {
 	resp, err := c.HTTPClient.R().
 		SetHeader("Accept", "application/json").
 		SetContext(ctx).
-		SetBody(&provisioning.PatchServiceEndpointsRequest{
-			{Mechanism: mechanism,
-				AllowedAccounts: allowedAccounts,
-				Visibility:      visibility},
-		}).
+		SetBody(&endpoints).
 		SetResult(provisioning.PatchServiceEndpointsResponse{}).
 		SetError(&ErrorResponse{}).
 		Patch("/provisioning/v1/services/" + serviceID + "/endpoints")
@@ -285,7 +427,7 @@ func (c *Client) ModifyServiceEndpoints(
 	if response == nil {
 		response = make(provisioning.PatchServiceEndpointsResponse, 0)
 	}
-	return &response[0], err
+	return response, err
 }
 
 func (c *Client) ModifyServiceSize(ctx context.Context, serviceID string, size string) error {
@@ -322,6 +464,40 @@ func (c *Client) ModifyServiceNodeNumber(ctx context.Context, serviceID string,
 	return err
 }
 
+func (c *Client) ModifyMaxscaleNodeNumber(ctx context.Context, serviceID string, nodes int64) error {
+	resp, err := c.HTTPClient.R().
+		SetHeader("Accept", "application/json").
+		SetContext(ctx).
+		SetBody(&provisioning.UpdateMaxscaleNodesNumberRequest{Nodes: nodes}).
+		SetError(&ErrorResponse{}).
+		Post("/provisioning/v1/services/" + serviceID + "/maxscale/nodes")
+	if err != nil {
+		return err
+	}
+	if resp.IsError() {
+		return handleError(resp)
+	}
+
+	return err
+}
+
+func (c *Client) ModifyMaxscaleSize(ctx context.Context, serviceID string, size string) error {
+	resp, err := c.HTTPClient.R().
+		SetHeader("Accept", "application/json").
+		SetContext(ctx).
+		SetBody(&provisioning.UpdateMaxscaleSizeRequest{Size: size}).
+		SetError(&ErrorResponse{}).
+		Post("/provisioning/v1/services/" + serviceID + "/maxscale/size")
+	if err != nil {
+		return err
+	}
+	if resp.IsError() {
+		return handleError(resp)
+	}
+
+	return err
+}
+
 func (c *Client) ModifyServiceStorage(ctx context.Context, serviceID string, size int64, iops int64, throughput int64) error {
 	resp, err := c.HTTPClient.R().
 		SetHeader("Accept", "application/json").
@@ -457,6 +633,26 @@ func (c *Client) CreateConfig(ctx context.Context, req *provisioning.CreateConfi
 	return resp.Result().(*provisioning.Config), nil
 }
 
+func (c *Client) GetConfigs(ctx context.Context, options ...func(url.Values)) ([]provisioning.Config, error) {
+	request := c.HTTPClient.R()
+	for _, option := range options {
+		option(request.QueryParam)
+	}
+	resp, err := request.
+		SetHeader("Accept", "application/json").
+		SetResult([]provisioning.Config{}).
+		SetError(&ErrorResponse{}).
+		SetContext(ctx).
+		Get("/provisioning/v1/configs")
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return nil, handleError(resp)
+	}
+	return *resp.Result().(*[]provisioning.Config), nil
+}
+
 func (c *Client) GetConfigByID(ctx context.Context, configID string) (*provisioning.Config, error) {
 	resp, err := c.HTTPClient.R().
 		SetHeader("Accept", "application/json").
@@ -598,3 +794,169 @@ func (c *Client) UnsetConfigValue(ctx context.Context, configID string, variable
 	}
 	return nil
 }
+
+func (c *Client) CreateBackup(ctx context.Context, req *backup.CreateBackupRequest) (*backup.Backup, error) {
+	resp, err := c.HTTPClient.R().
+		SetHeader("Accept", "application/json").
+		SetResult(backup.Backup{}).
+		SetError(&ErrorResponse{}).
+		SetContext(ctx).
+		SetBody(req).
+		Post("/skybackup/v1/backups")
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return nil, handleError(resp)
+	}
+	return resp.Result().(*backup.Backup), nil
+}
+
+func (c *Client) GetBackupByID(ctx context.Context, backupID string) (*backup.Backup, error) {
+	resp, err := c.HTTPClient.R().
+		SetHeader("Accept", "application/json").
+		SetResult(backup.Backup{}).
+		SetError(&ErrorResponse{}).
+		SetContext(ctx).
+		Get("/skybackup/v1/backups/" + backupID)
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return nil, handleError(resp)
+	}
+	return resp.Result().(*backup.Backup), nil
+}
+
+func (c *Client) CreateBackupSchedule(ctx context.Context, req *backup.CreateScheduleRequest) (*backup.Schedule, error) {
+	resp, err := c.HTTPClient.R().
+		SetHeader("Accept", "application/json").
+		SetResult(backup.Schedule{}).
+		SetError(&ErrorResponse{}).
+		SetContext(ctx).
+		SetBody(req).
+		Post("/skybackup/v1/backups/schedules")
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return nil, handleError(resp)
+	}
+	return resp.Result().(*backup.Schedule), nil
+}
+
+func (c *Client) GetBackupScheduleByID(ctx context.Context, scheduleID string) (*backup.Schedule, error) {
+	resp, err := c.HTTPClient.R().
+		SetHeader("Accept", "application/json").
+		SetResult(backup.Schedule{}).
+		SetError(&ErrorResponse{}).
+		SetContext(ctx).
+		Get("/skybackup/v1/backups/schedules/" + scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return nil, handleError(resp)
+	}
+	return resp.Result().(*backup.Schedule), nil
+}
+
+func (c *Client) UpdateBackupSchedule(ctx context.Context, scheduleID string, req *backup.UpdateScheduleRequest) (*backup.Schedule, error) {
+	resp, err := c.HTTPClient.R().
+		SetHeader("Accept", "application/json").
+		SetResult(backup.Schedule{}).
+		SetError(&ErrorResponse{}).
+		SetContext(ctx).
+		SetBody(req).
+		Patch("/skybackup/v1/backups/schedules/" + scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return nil, handleError(resp)
+	}
+	return resp.Result().(*backup.Schedule), nil
+}
+
+func (c *Client) DeleteBackupSchedule(ctx context.Context, scheduleID string) error {
+	resp, err := c.HTTPClient.R().
+		SetHeader("Accept", "application/json").
+		SetError(&ErrorResponse{}).
+		SetContext(ctx).
+		Delete("/skybackup/v1/backups/schedules/" + scheduleID)
+	if err != nil {
+		return err
+	}
+	if resp.IsError() {
+		return handleError(resp)
+	}
+	return nil
+}
+
+// GetBackups lists the backups of a service. It follows next_page_token until
+// the last page and returns the backups of all pages.
+func (c *Client) GetBackups(ctx context.Context, serviceID string, options ...func(url.Values)) ([]backup.Backup, error) {
+	backups := make([]backup.Backup, 0)
+	pageToken := ""
+	for {
+		request := c.HTTPClient.R()
+		for _, option := range options {
+			option(request.QueryParam)
+		}
+		request.QueryParam.Set("service_id", serviceID)
+		if pageToken != "" {
+			request.QueryParam.Set("page_token", pageToken)
+		}
+		resp, err := request.
+			SetHeader("Accept", "application/json").
+			SetResult(backup.List{}).
+			SetError(&ErrorResponse{}).
+			SetContext(ctx).
+			Get("/skybackup/v1/backups")
+		if err != nil {
+			return nil, err
+		}
+		if resp.IsError() {
+			return nil, handleError(resp)
+		}
+		page := resp.Result().(*backup.List)
+		backups = append(backups, page.Backups...)
+		if page.NextPageToken == "" || page.NextPageToken == pageToken {
+			return backups, nil
+		}
+		pageToken = page.NextPageToken
+	}
+}
+
+func (c *Client) CreateRestore(ctx context.Context, req *backup.CreateRestoreRequest) (*backup.Restore, error) {
+	resp, err := c.HTTPClient.R().
+		SetHeader("Accept", "application/json").
+		SetResult(backup.Restore{}).
+		SetError(&ErrorResponse{}).
+		SetContext(ctx).
+		SetBody(req).
+		Post("/skybackup/v1/restores")
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return nil, handleError(resp)
+	}
+	return resp.Result().(*backup.Restore), nil
+}
+
+func (c *Client) GetRestoreByID(ctx context.Context, restoreID string) (*backup.Restore, error) {
+	resp, err := c.HTTPClient.R().
+		SetHeader("Accept", "application/json").
+		SetResult(backup.Restore{}).
+		SetError(&ErrorResponse{}).
+		SetContext(ctx).
+		Get("/skybackup/v1/restores/" + restoreID)
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return nil, handleError(resp)
+	}
+	return resp.Result().(*backup.Restore), nil
+}
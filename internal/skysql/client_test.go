@@ -1,8 +1,10 @@
 package skysql
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -387,6 +389,27 @@ func TestHandleError401(t *testing.T) {
 	}
 }
 
+func TestHandleError409IsConflict(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(ErrorResponse{
+			Errors: []ErrorDetails{{Message: "allow list was modified"}},
+		})
+	}))
+	defer srv.Close()
+
+	client := New(srv.URL, "test-key", "")
+
+	_, err := client.UpdateServiceAllowListByID(context.Background(), "svc-123", nil)
+	if !errors.Is(err, ErrorConflict) {
+		t.Errorf("expected ErrorConflict, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "allow list was modified") {
+		t.Errorf("expected error to contain API message, got: %q", err.Error())
+	}
+}
+
 func TestHandleError500IncludesMessage(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -433,3 +456,58 @@ func TestHandleErrorEmptyBody(t *testing.T) {
 		t.Errorf("expected error to mention 500, got: %q", err.Error())
 	}
 }
+
+func TestGetBackupsFollowsPages(t *testing.T) {
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		switch r.URL.Query().Get("page_token") {
+		case "":
+			w.Write([]byte(`{"backups":[{"id":"bkp-1"},{"id":"bkp-2"}],"next_page_token":"page-2"}`))
+		case "page-2":
+			w.Write([]byte(`{"backups":[{"id":"bkp-3"}]}`))
+		default:
+			t.Errorf("unexpected page token %q", r.URL.Query().Get("page_token"))
+		}
+	}))
+	defer srv.Close()
+
+	client := New(srv.URL, "test-key", "")
+
+	backups, err := client.GetBackups(context.Background(), "svc-123", WithStatus("succeeded"))
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+	if len(backups) != 3 || backups[0].ID != "bkp-1" || backups[2].ID != "bkp-3" {
+		t.Errorf("expected backups bkp-1, bkp-2 and bkp-3, got %+v", backups)
+	}
+
+	expected := []string{
+		"service_id=svc-123&status=succeeded",
+		"page_token=page-2&service_id=svc-123&status=succeeded",
+	}
+	if strings.Join(queries, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected queries %q, got %q", expected, queries)
+	}
+}
+
+func TestModifyMaxscaleNodeNumberSendsZero(t *testing.T) {
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload, _ := io.ReadAll(r.Body)
+		body = string(payload)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := New(srv.URL, "test-key", "")
+
+	if err := client.ModifyMaxscaleNodeNumber(context.Background(), "svc-123", 0); err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+	if body != `{"nodes":0}` {
+		t.Errorf("expected an explicit zero, got %s", body)
+	}
+}
@@ -24,3 +24,7 @@ type ErrorDetails struct {
 var ErrorServiceNotFound = errors.New("service not found")
 
 var ErrorUnauthorized = errors.New("skysql returns unauthorized error")
+
+// ErrorConflict is returned when a change was rejected because the object was
+// modified concurrently.
+var ErrorConflict = errors.New("concurrent modification conflict")
@@ -26,4 +26,6 @@ type CreateServiceRequest struct {
 	MaxscaleSize       *string         `json:"maxscale_size,omitempty"`
 	AvailabilityZone   string            `json:"availability_zone,omitempty"`
 	Tags               map[string]string `json:"tags,omitempty"`
+	RestoreFrom        *RestoreSource    `json:"restore_from,omitempty"`
+	MaintenanceWindow  *MaintenanceWindow `json:"maintenance_window,omitempty"`
 }
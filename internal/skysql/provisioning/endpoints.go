@@ -2,10 +2,13 @@ package provisioning
 
 // ServiceEndpoint is service endpoint dto
 type ServiceEndpoint struct {
+	Name            string   `json:"name,omitempty"`
 	Mechanism       string   `json:"mechanism,omitempty"`
 	AllowedAccounts []string `json:"allowed_accounts,omitempty"`
 	Visibility      string   `json:"visibility"`
 	EndpointService string   `json:"endpoint_service,omitempty"`
+	// AllowList is left out of the request when nil, an empty list clears it.
+	AllowList *[]AllowListItem `json:"allow_list,omitempty"`
 }
 
 // PatchServiceEndpointsRequest godoc
@@ -0,0 +1,11 @@
+package provisioning
+
+// MaintenanceWindow is the weekly window SkySQL runs patching and other
+// maintenance of a service in.
+type MaintenanceWindow struct {
+	DayOfWeek     string `json:"day_of_week"`
+	StartHour     int64  `json:"start_hour"`
+	DurationHours int64  `json:"duration_hours"`
+	// TimeZone is an IANA time zone name, UTC when empty.
+	TimeZone string `json:"time_zone,omitempty"`
+}
@@ -0,0 +1,20 @@
+package provisioning
+
+// NodeSizeTypeServer is the type of the sizes of database nodes, MaxScale
+// nodes use sizes of type proxy.
+const NodeSizeTypeServer = "server"
+
+type NodeSize struct {
+	ID                      string `json:"id"`
+	Name                    string `json:"name"`
+	DisplayName             string `json:"display_name"`
+	ServiceType             string `json:"service_type"`
+	Provider                string `json:"provider"`
+	Tier                    string `json:"tier"`
+	Architecture            string `json:"architecture"`
+	CPU                     string `json:"cpu"`
+	RAM                     string `json:"ram"`
+	Type                    string `json:"type"`
+	DefaultMaxscaleSizeName string `json:"default_maxscale_size_name"`
+	IsActive                bool   `json:"is_active"`
+}
@@ -0,0 +1,9 @@
+package provisioning
+
+// RestoreSource is the data a new service is created from, either a backup or
+// the state of another service at a point in time.
+type RestoreSource struct {
+	BackupID        string `json:"backup_id,omitempty"`
+	SourceServiceID string `json:"source_service_id,omitempty"`
+	PointInTime     string `json:"point_in_time,omitempty"`
+}
@@ -3,6 +3,7 @@ package provisioning
 type Service struct {
 	ID            string     `json:"id"`
 	Name          string     `json:"name"`
+	ProjectID     string     `json:"project_id,omitempty"`
 	Region        string     `json:"region"`
 	Provider      string     `json:"provider"`
 	Tier          string     `json:"tier"`
@@ -36,6 +37,9 @@ type Service struct {
 	AvailabilityZone   string            `json:"availability_zone,omitempty"`
 	Tags               map[string]string `json:"tags,omitempty"`
 	ConfigID           string            `json:"config_id,omitempty"`
+	// RestoreID is the restore job started by a create request with restore_from.
+	RestoreID         string             `json:"restore_id,omitempty"`
+	MaintenanceWindow *MaintenanceWindow `json:"maintenance_window,omitempty"`
 }
 
 type Endpoint struct {
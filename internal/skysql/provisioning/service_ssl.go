@@ -0,0 +1,5 @@
+package provisioning
+
+type SSLRequest struct {
+	SSLEnabled bool `json:"ssl_enabled"`
+}
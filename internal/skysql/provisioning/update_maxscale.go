@@ -0,0 +1,9 @@
+package provisioning
+
+type UpdateMaxscaleNodesNumberRequest struct {
+	Nodes int64 `json:"nodes"`
+}
+
+type UpdateMaxscaleSizeRequest struct {
+	Size string `json:"size,omitempty"`
+}